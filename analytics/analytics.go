@@ -0,0 +1,380 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// Method margin removal method used to compute fair probabilities
+type Method string
+
+// Multiplicative removes the margin proportionally to the implied probabilities
+const Multiplicative Method = "multiplicative"
+
+// Additive removes an equal share of the margin from each implied probability
+const Additive Method = "additive"
+
+// Power raises implied probabilities to the power k that makes them sum to 1, more margin is removed from longshots
+const Power Method = "power"
+
+// Shin removes the margin assuming a proportion of insider trading (Shin 1993), more margin is removed from longshots
+const Shin Method = "shin"
+
+// ErrNoPricedOutcomes returned when a market has no outcomes with odds
+var ErrNoPricedOutcomes = errors.New("market has no priced outcomes")
+
+// maxIterations bisection iterations, enough for float64 precision
+const maxIterations = 100
+
+// OutcomeAnalytics probabilities and fair odds of a market outcome
+type OutcomeAnalytics struct {
+	OutcomeID          string  `json:"outcome_id"`
+	OutcomeName        string  `json:"outcome_name"`
+	Odds               float64 `json:"odds"`
+	ImpliedProbability float64 `json:"implied_probability"`
+	FairProbability    float64 `json:"fair_probability"`
+	FairOdds           float64 `json:"fair_odds"`
+}
+
+// MarketAnalytics book percentage, overround and fair odds of a market
+type MarketAnalytics struct {
+	MarketID       int64              `json:"market_id"`
+	MarketName     string             `json:"market_name"`
+	Specifier      string             `json:"specifier"`
+	Method         Method             `json:"method"`
+	BookPercentage float64            `json:"book_percentage"`
+	Overround      float64            `json:"overround"`
+	Outcomes       []OutcomeAnalytics `json:"outcomes"`
+}
+
+// BookPercentage gets the sum of the implied probabilities of all priced outcomes of the market e.g 1.05 for a 105% book
+func BookPercentage(market models.Market) float64 {
+
+	total := 0.0
+
+	for _, p := range ImpliedProbabilities(market) {
+
+		total += p
+	}
+
+	return total
+}
+
+// Overround gets the bookmaker margin of the market e.g 0.05 for a 105% book
+func Overround(market models.Market) float64 {
+
+	book := BookPercentage(market)
+	if book == 0 {
+
+		return 0
+	}
+
+	return book - 1
+}
+
+// ImpliedProbabilities gets 1/odds of each outcome of the market, outcomes without odds have a probability of 0
+func ImpliedProbabilities(market models.Market) []float64 {
+
+	probabilities := make([]float64, len(market.Outcomes))
+
+	for i, o := range market.Outcomes {
+
+		if o.Odds > 0 {
+
+			probabilities[i] = 1 / o.Odds
+		}
+	}
+
+	return probabilities
+}
+
+// FairProbabilities gets the de-margined probabilities of each outcome of the market using the supplied method,
+// outcomes without odds have a probability of 0
+func FairProbabilities(market models.Market, method Method) ([]float64, error) {
+
+	implied := ImpliedProbabilities(market)
+
+	var priced []float64
+
+	for _, p := range implied {
+
+		if p > 0 {
+
+			priced = append(priced, p)
+		}
+	}
+
+	if len(priced) == 0 {
+
+		return nil, ErrNoPricedOutcomes
+	}
+
+	var fair []float64
+
+	switch method {
+
+	case Multiplicative, "":
+		fair = multiplicative(priced)
+
+	case Additive:
+		fair = additive(priced)
+
+	case Power:
+		fair = power(priced)
+
+	case Shin:
+		fair = shin(priced)
+
+	default:
+		return nil, fmt.Errorf("unknown margin removal method %s", method)
+
+	}
+
+	probabilities := make([]float64, len(implied))
+
+	x := 0
+
+	for i, p := range implied {
+
+		if p > 0 {
+
+			probabilities[i] = fair[x]
+			x++
+		}
+	}
+
+	return probabilities, nil
+}
+
+// FairOdds gets the de-margined odds of each outcome of the market using the supplied method,
+// outcomes without odds have fair odds of 0
+func FairOdds(market models.Market, method Method) ([]float64, error) {
+
+	probabilities, err := FairProbabilities(market, method)
+	if err != nil {
+
+		return nil, err
+	}
+
+	odds := make([]float64, len(probabilities))
+
+	for i, p := range probabilities {
+
+		if p > 0 {
+
+			odds[i] = 1 / p
+		}
+	}
+
+	return odds, nil
+}
+
+// AnalyseMarket computes book percentage, overround, implied and fair probabilities of the market
+func AnalyseMarket(market models.Market, method Method) (*MarketAnalytics, error) {
+
+	implied := ImpliedProbabilities(market)
+
+	fair, err := FairProbabilities(market, method)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if len(method) == 0 {
+
+		method = Multiplicative
+	}
+
+	analytics := &MarketAnalytics{
+		MarketID:       market.MarketID,
+		MarketName:     market.MarketName,
+		Specifier:      market.Specifier,
+		Method:         method,
+		BookPercentage: BookPercentage(market),
+		Overround:      Overround(market),
+	}
+
+	for i, o := range market.Outcomes {
+
+		fairOdds := 0.0
+
+		if fair[i] > 0 {
+
+			fairOdds = 1 / fair[i]
+		}
+
+		analytics.Outcomes = append(analytics.Outcomes, OutcomeAnalytics{
+			OutcomeID:          o.OutcomeID,
+			OutcomeName:        o.OutcomeName,
+			Odds:               o.Odds,
+			ImpliedProbability: implied[i],
+			FairProbability:    fair[i],
+			FairOdds:           fairOdds,
+		})
+	}
+
+	return analytics, nil
+}
+
+// AnalyseMarkets computes analytics for each of the supplied markets, markets without priced outcomes are skipped
+func AnalyseMarkets(markets []models.Market, method Method) []MarketAnalytics {
+
+	var analytics []MarketAnalytics
+
+	for _, m := range markets {
+
+		a, err := AnalyseMarket(m, method)
+		if err != nil {
+
+			continue
+		}
+
+		analytics = append(analytics, *a)
+	}
+
+	return analytics
+}
+
+// GetMarketAnalytics gets analytics for all markets of the supplied match
+func GetMarketAnalytics(feed feeds.Feed, producerID, matchID int64, method Method) []MarketAnalytics {
+
+	return AnalyseMarkets(feed.GetAllMarkets(producerID, matchID), method)
+}
+
+func multiplicative(implied []float64) []float64 {
+
+	book := sum(implied)
+
+	fair := make([]float64, len(implied))
+
+	for i, p := range implied {
+
+		fair[i] = p / book
+	}
+
+	return fair
+}
+
+// additive removes (book - 1) / n from each probability, probabilities that would become negative are set to 0
+func additive(implied []float64) []float64 {
+
+	share := (sum(implied) - 1) / float64(len(implied))
+
+	fair := make([]float64, len(implied))
+
+	for i, p := range implied {
+
+		fair[i] = math.Max(p-share, 0)
+	}
+
+	return fair
+}
+
+// power finds k such that the sum of p^k is 1 using bisection, the sum decreases as k increases
+func power(implied []float64) []float64 {
+
+	powSum := func(k float64) float64 {
+
+		total := 0.0
+
+		for _, p := range implied {
+
+			total += math.Pow(p, k)
+		}
+
+		return total
+	}
+
+	low, high := 0.0, 1.0
+
+	// expand the upper bound until it brackets the root, k is above 1 for books above 100%
+	for powSum(high) > 1 && high < 1e6 {
+
+		low = high
+		high *= 2
+	}
+
+	for i := 0; i < maxIterations; i++ {
+
+		k := (low + high) / 2
+
+		if powSum(k) > 1 {
+
+			low = k
+
+		} else {
+
+			high = k
+		}
+	}
+
+	k := (low + high) / 2
+
+	fair := make([]float64, len(implied))
+
+	for i, p := range implied {
+
+		fair[i] = math.Pow(p, k)
+	}
+
+	return fair
+}
+
+// shin finds the insider trading proportion z such that the Shin probabilities sum to 1 using bisection
+func shin(implied []float64) []float64 {
+
+	book := sum(implied)
+
+	// books at or below 100% have no margin to remove
+	if book <= 1 {
+
+		return multiplicative(implied)
+	}
+
+	probabilities := func(z float64) []float64 {
+
+		p := make([]float64, len(implied))
+
+		for i, pi := range implied {
+
+			p[i] = (math.Sqrt(z*z+4*(1-z)*pi*pi/book) - z) / (2 * (1 - z))
+		}
+
+		return p
+	}
+
+	// the sum of the probabilities decreases from sqrt(book) at z = 0
+	low, high := 0.0, 1.0
+
+	for i := 0; i < maxIterations; i++ {
+
+		z := (low + high) / 2
+
+		if sum(probabilities(z)) > 1 {
+
+			low = z
+
+		} else {
+
+			high = z
+		}
+	}
+
+	return probabilities((low + high) / 2)
+}
+
+func sum(values []float64) float64 {
+
+	total := 0.0
+
+	for _, v := range values {
+
+		total += v
+	}
+
+	return total
+}
@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// OverroundThresholds acceptable overround range of an active market, markets outside the range are flagged
+type OverroundThresholds struct {
+	Min float64
+	Max float64
+}
+
+// ThresholdsFromEnv gets overround thresholds from ODDS_OVERROUND_MIN and ODDS_OVERROUND_MAX,
+// returns nil (checks disabled) if neither is set. Min defaults to 0 (arbitrage) and Max to 0.3
+func ThresholdsFromEnv() *OverroundThresholds {
+
+	minValue := os.Getenv("ODDS_OVERROUND_MIN")
+	maxValue := os.Getenv("ODDS_OVERROUND_MAX")
+
+	if len(minValue) == 0 && len(maxValue) == 0 {
+
+		return nil
+	}
+
+	thresholds := &OverroundThresholds{
+		Min: 0,
+		Max: 0.3,
+	}
+
+	if v, err := strconv.ParseFloat(minValue, 64); err == nil {
+
+		thresholds.Min = v
+
+	} else if len(minValue) > 0 {
+
+		log.Printf("invalid ODDS_OVERROUND_MIN %s ", err.Error())
+	}
+
+	if v, err := strconv.ParseFloat(maxValue, 64); err == nil {
+
+		thresholds.Max = v
+
+	} else if len(maxValue) > 0 {
+
+		log.Printf("invalid ODDS_OVERROUND_MAX %s ", err.Error())
+	}
+
+	return thresholds
+}
+
+// CheckMarkets gets analytics of the active markets whose overround is outside the thresholds.
+// only active markets with all outcomes active and priced are checked, partial books have no meaningful overround
+func (t *OverroundThresholds) CheckMarkets(markets []models.Market) []MarketAnalytics {
+
+	if t == nil {
+
+		return nil
+	}
+
+	var flagged []MarketAnalytics
+
+	for _, m := range markets {
+
+		if m.Status != 0 || len(m.Outcomes) < 2 || !fullyPriced(m) {
+
+			continue
+		}
+
+		overround := Overround(m)
+		if overround >= t.Min && overround <= t.Max {
+
+			continue
+		}
+
+		a, err := AnalyseMarket(m, Multiplicative)
+		if err != nil {
+
+			continue
+		}
+
+		flagged = append(flagged, *a)
+	}
+
+	return flagged
+}
+
+func fullyPriced(market models.Market) bool {
+
+	for _, o := range market.Outcomes {
+
+		if o.Active != 1 || o.Odds <= 1 {
+
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrConfirmationRequired returned when a namespace wide delete is attempted without the expected confirmation token
+var ErrConfirmationRequired = errors.New("namespace wide delete requires a confirmation token")
+
+// ErrDeleteAllDisabled returned when a namespace wide delete is attempted and FEEDS_DELETE_ALL_TOKEN is not set
+var ErrDeleteAllDisabled = errors.New("namespace wide delete is disabled, FEEDS_DELETE_ALL_TOKEN is not set")
+
+// Entry record of a mutating feed call
+type Entry struct {
+
+	// Caller identity of the service that made the call
+	Caller string `json:"caller"`
+
+	// Backend feed implementation e.g redis or mysql
+	Backend string `json:"backend"`
+
+	// Namespace feed namespace or database the call mutated
+	Namespace string `json:"namespace"`
+
+	// Action name of the mutating method e.g DeleteMatchOdds
+	Action string `json:"action"`
+
+	// Arguments arguments the method was called with
+	Arguments map[string]interface{} `json:"arguments"`
+
+	// Affected number of keys or rows affected
+	Affected int64 `json:"affected"`
+
+	// Error error returned by the call, empty on success
+	Error string `json:"error,omitempty"`
+
+	// Timestamp time of the call in milliseconds
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Sink destination of audit entries
+type Sink interface {
+	Write(entry Entry) error
+}
+
+// Logger records mutating feed calls to the configured sinks, a nil Logger records nothing
+type Logger struct {
+	Caller    string
+	Backend   string
+	Namespace string
+	Sinks     []Sink
+}
+
+// NewLogger creates an audit logger for the supplied backend and namespace,
+// Caller is taken from FEEDS_AUDIT_CALLER and defaults to process@hostname
+func NewLogger(backend, namespace string, sinks ...Sink) *Logger {
+
+	return &Logger{
+		Caller:    DefaultCaller(),
+		Backend:   backend,
+		Namespace: namespace,
+		Sinks:     sinks,
+	}
+}
+
+// DefaultCaller gets the caller identity from FEEDS_AUDIT_CALLER, defaults to process@hostname
+func DefaultCaller() string {
+
+	if caller := os.Getenv("FEEDS_AUDIT_CALLER"); len(caller) > 0 {
+
+		return caller
+	}
+
+	hostname, _ := os.Hostname()
+
+	return fmt.Sprintf("%s@%s", filepath.Base(os.Args[0]), hostname)
+}
+
+// Record writes an entry for the supplied action to all sinks, sink errors are logged and not returned
+// so that auditing never fails the audited call
+func (l *Logger) Record(action string, arguments map[string]interface{}, affected int64, err error) {
+
+	if l == nil || len(l.Sinks) == 0 {
+
+		return
+	}
+
+	entry := Entry{
+		Caller:    l.Caller,
+		Backend:   l.Backend,
+		Namespace: l.Namespace,
+		Action:    action,
+		Arguments: arguments,
+		Affected:  affected,
+		Timestamp: time.Now().UnixMilli(),
+	}
+
+	if err != nil {
+
+		entry.Error = err.Error()
+	}
+
+	for _, sink := range l.Sinks {
+
+		if werr := sink.Write(entry); werr != nil {
+
+			log.Printf("error writing audit entry %s %s | %s", l.Backend, action, werr.Error())
+		}
+	}
+}
+
+// Confirm checks the supplied token confirms deleting all data of the namespace. The token is the secret set by
+// the operator in FEEDS_DELETE_ALL_TOKEN, deletes are disabled when it is not set
+func Confirm(namespace, token string) error {
+
+	expected := os.Getenv("FEEDS_DELETE_ALL_TOKEN")
+	if len(expected) == 0 {
+
+		return ErrDeleteAllDisabled
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+
+		return fmt.Errorf("%w to delete %s", ErrConfirmationRequired, namespace)
+	}
+
+	return nil
+}
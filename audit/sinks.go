@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	goutils "github.com/mudphilo/go-utils"
+	"github.com/touchvas/odds-sdk/v2/utils"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FileSink appends audit entries to a file as JSON lines
+type FileSink struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// Write appends the entry to the file
+func (s *FileSink) Write(entry Entry) error {
+
+	js, err := json.Marshal(entry)
+	if err != nil {
+
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+
+		return fmt.Errorf("error opening audit file %s | %s", s.Path, err)
+	}
+
+	defer f.Close()
+
+	_, err = f.Write(append(js, '\n'))
+	return err
+}
+
+// RedisStreamSink appends audit entries to a redis stream
+type RedisStreamSink struct {
+	RedisClient utils.Redis
+	Stream      string
+	MaxLength   int64
+}
+
+// Write appends the entry to the stream
+func (s *RedisStreamSink) Write(entry Entry) error {
+
+	arguments, _ := json.Marshal(entry.Arguments)
+
+	return utils.AddRedisStream(context.Background(), s.RedisClient, s.Stream, map[string]interface{}{
+		"caller":    entry.Caller,
+		"backend":   entry.Backend,
+		"namespace": entry.Namespace,
+		"action":    entry.Action,
+		"arguments": string(arguments),
+		"affected":  entry.Affected,
+		"error":     entry.Error,
+		"timestamp": entry.Timestamp,
+	}, s.MaxLength)
+}
+
+// MysqlSink inserts audit entries to a mysql table, see README for the table definition
+type MysqlSink struct {
+	DB    *sql.DB
+	Table string
+}
+
+// Write inserts the entry to the table
+func (s *MysqlSink) Write(entry Entry) error {
+
+	arguments, _ := json.Marshal(entry.Arguments)
+
+	dbUtils := goutils.Db{DB: s.DB, Context: context.TODO()}
+
+	_, err := dbUtils.InsertWithContext(s.Table, map[string]interface{}{
+		"caller":    entry.Caller,
+		"backend":   entry.Backend,
+		"namespace": entry.Namespace,
+		"action":    entry.Action,
+		"arguments": string(arguments),
+		"affected":  entry.Affected,
+		"error":     entry.Error,
+		"timestamp": entry.Timestamp,
+	})
+
+	return err
+}
+
+// SinksFromEnv creates the sinks listed in FEEDS_AUDIT_SINKS (comma separated file, redis and mysql).
+// FEEDS_AUDIT_FILE, FEEDS_AUDIT_STREAM and FEEDS_AUDIT_TABLE override the default file, stream and table names.
+// the mysql sink is skipped if db is nil
+func SinksFromEnv(redisClient utils.Redis, db *sql.DB) []Sink {
+
+	var sinks []Sink
+
+	for _, name := range strings.Split(os.Getenv("FEEDS_AUDIT_SINKS"), ",") {
+
+		switch strings.TrimSpace(strings.ToLower(name)) {
+
+		case "":
+
+		case "file":
+			sinks = append(sinks, &FileSink{Path: envOrDefault("FEEDS_AUDIT_FILE", "feeds-audit.log")})
+
+		case "redis":
+			sinks = append(sinks, &RedisStreamSink{
+				RedisClient: redisClient,
+				Stream:      envOrDefault("FEEDS_AUDIT_STREAM", "feeds-audit"),
+				MaxLength:   100000,
+			})
+
+		case "mysql":
+			if db == nil {
+
+				log.Printf("mysql audit sink requires a mysql connection, skipping")
+				continue
+			}
+
+			sinks = append(sinks, &MysqlSink{DB: db, Table: envOrDefault("FEEDS_AUDIT_TABLE", "feeds_audit")})
+
+		default:
+			log.Printf("unknown audit sink %s ", name)
+
+		}
+	}
+
+	return sinks
+}
+
+func envOrDefault(name, defaultValue string) string {
+
+	if value := os.Getenv(name); len(value) > 0 {
+
+		return value
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// DefaultMaxEntries number of cached reads when Config.MaxEntries is not set
+const DefaultMaxEntries = 10000
+
+// DefaultTTL time a read is cached when Config.TTL is not set, the longest a read can be stale when an invalidation is lost
+const DefaultTTL = 2 * time.Second
+
+// Config settings of the read cache
+type Config struct {
+
+	// MaxEntries maximum number of cached reads, least recently used reads are evicted first
+	MaxEntries int
+
+	// TTL time a read is cached
+	TTL time.Duration
+}
+
+// ConfigFromEnv gets the cache settings from FEEDS_CACHE_MAX_ENTRIES and FEEDS_CACHE_TTL_MS
+func ConfigFromEnv() Config {
+
+	maxEntries, _ := strconv.Atoi(os.Getenv("FEEDS_CACHE_MAX_ENTRIES"))
+	ttl, _ := strconv.ParseInt(os.Getenv("FEEDS_CACHE_TTL_MS"), 10, 64)
+
+	return Config{
+		MaxEntries: maxEntries,
+		TTL:        time.Duration(ttl) * time.Millisecond,
+	}
+}
+
+// Stats hit and miss counters of the read cache
+type Stats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Expired       int64 `json:"expired"`
+	Evictions     int64 `json:"evictions"`
+	Invalidations int64 `json:"invalidations"`
+	Entries       int64 `json:"entries"`
+}
+
+// HitRate gets the share of reads served from the cache
+func (s Stats) HitRate() float64 {
+
+	if s.Hits+s.Misses == 0 {
+
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// CachedFeed serves GetAllMarkets and GetMarket of hot matches from memory. Cached reads of a match are dropped
+// when OddsChange, BetStop or a delete of the match is applied by any instance, each instance publishes to
+// odds_invalidation and every CachedFeed subscribed to it drops the match. Reads are at most TTL stale
+// if an invalidation is lost. Empty reads are not cached so that odds recovery is still requested
+type CachedFeed struct {
+	feeds.Feed
+
+	cache        *lru
+	subscription *nats.Subscription
+
+	hits          int64
+	misses        int64
+	expired       int64
+	evictions     int64
+	invalidations int64
+}
+
+// NewCachedFeed creates a read cache in front of feed, invalidations are received over nc when it is not nil
+func NewCachedFeed(feed feeds.Feed, nc *nats.Conn, cfg Config) (*CachedFeed, error) {
+
+	if cfg.MaxEntries <= 0 {
+
+		cfg.MaxEntries = DefaultMaxEntries
+	}
+
+	if cfg.TTL <= 0 {
+
+		cfg.TTL = DefaultTTL
+	}
+
+	c := &CachedFeed{
+		Feed:  feed,
+		cache: newLRU(cfg.MaxEntries, cfg.TTL),
+	}
+
+	if nc == nil {
+
+		return c, nil
+	}
+
+	// a plain subscription, every instance has to receive every invalidation
+	subscription, err := nc.Subscribe(utils.NatsSubject(constants.OddsInvalidationTopic), func(msg *nats.Msg) {
+
+		var invalidation models.OddsInvalidation
+
+		err := json.Unmarshal(msg.Data, &invalidation)
+		if err != nil {
+
+			log.Printf("error decoding odds invalidation %s | %s", string(msg.Data), err.Error())
+			return
+		}
+
+		c.Invalidate(invalidation.MatchID, invalidation.ProducerID)
+	})
+	if err != nil {
+
+		log.Printf("error subscribing to odds invalidation %s", err.Error())
+		return nil, err
+	}
+
+	c.subscription = subscription
+
+	return c, nil
+}
+
+// ConfigVersion gets the configuration version of the wrapped feed
+func (c *CachedFeed) ConfigVersion() string {
+
+	return feeds.ConfigVersion(c.Feed)
+}
+
+// GetAllMarkets gets all markets for a particular matchID from the cache or the wrapped feed
+func (c *CachedFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
+
+	key := entryKey{producerID: producerID, matchID: matchID, all: true}
+
+	if value, ok := c.get(key); ok {
+
+		return copyMarkets(value.([]models.Market))
+	}
+
+	readStart := time.Now()
+
+	markets := c.Feed.GetAllMarkets(producerID, matchID)
+	if len(markets) > 0 {
+
+		c.add(key, copyMarkets(markets), readStart)
+	}
+
+	return markets
+}
+
+// GetMarket gets market for a particular matchID and marketID from the cache or the wrapped feed
+func (c *CachedFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
+
+	key := entryKey{producerID: producerID, matchID: matchID, marketID: marketID, specifier: specifier}
+
+	if value, ok := c.get(key); ok {
+
+		market := copyMarket(*value.(*models.Market))
+		return &market
+	}
+
+	readStart := time.Now()
+
+	market := c.Feed.GetMarket(producerID, matchID, marketID, specifier)
+	if market != nil {
+
+		cached := copyMarket(*market)
+		c.add(key, &cached, readStart)
+	}
+
+	return market
+}
+
+// OddsChange applies the odds change to the wrapped feed and drops the cached reads of the match
+func (c *CachedFeed) OddsChange(odds models.OddsChange) (int, error) {
+
+	count, err := c.Feed.OddsChange(odds)
+	c.Invalidate(odds.MatchID, odds.ProducerID)
+	return count, err
+}
+
+// BetStop applies the bet stop to the wrapped feed and drops the cached reads of the match
+func (c *CachedFeed) BetStop(producerID, matchID, status int64, statusName string, betradarTimeStamp, publishTimestamp, publisherProcessingTime, networkLatency int64) error {
+
+	err := c.Feed.BetStop(producerID, matchID, status, statusName, betradarTimeStamp, publishTimestamp, publisherProcessingTime, networkLatency)
+	c.Invalidate(matchID, producerID)
+	return err
+}
+
+// DeleteAllMarkets deletes the markets in the wrapped feed and drops the cached reads of the match
+func (c *CachedFeed) DeleteAllMarkets(producerID, matchID int64) error {
+
+	err := c.Feed.DeleteAllMarkets(producerID, matchID)
+	c.Invalidate(matchID, producerID)
+	return err
+}
+
+// DeleteMatchOdds deletes the odds in the wrapped feed and drops the cached reads of the match
+func (c *CachedFeed) DeleteMatchOdds(matchID int64) {
+
+	c.Feed.DeleteMatchOdds(matchID)
+	c.Invalidate(matchID, 0)
+}
+
+// Invalidate drops the cached reads of the match, producerID 0 drops the reads of all producers
+func (c *CachedFeed) Invalidate(matchID, producerID int64) {
+
+	removed := c.cache.invalidate(matchID, producerID)
+	atomic.AddInt64(&c.invalidations, int64(removed))
+}
+
+// Stats gets the cache counters since the cache was created
+func (c *CachedFeed) Stats() Stats {
+
+	return Stats{
+		Hits:          atomic.LoadInt64(&c.hits),
+		Misses:        atomic.LoadInt64(&c.misses),
+		Expired:       atomic.LoadInt64(&c.expired),
+		Evictions:     atomic.LoadInt64(&c.evictions),
+		Invalidations: atomic.LoadInt64(&c.invalidations),
+		Entries:       int64(c.cache.len()),
+	}
+}
+
+// Close stops receiving invalidations
+func (c *CachedFeed) Close() error {
+
+	if c.subscription == nil {
+
+		return nil
+	}
+
+	return c.subscription.Unsubscribe()
+}
+
+func (c *CachedFeed) get(key entryKey) (interface{}, bool) {
+
+	value, ok, expired := c.cache.get(key)
+
+	if expired {
+
+		atomic.AddInt64(&c.expired, 1)
+	}
+
+	if !ok {
+
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return value, true
+}
+
+func (c *CachedFeed) add(key entryKey, value interface{}, readStart time.Time) {
+
+	_, evicted := c.cache.add(key, value, readStart)
+	atomic.AddInt64(&c.evictions, int64(evicted))
+}
+
+// copyMarkets copies the markets and their outcomes so that callers can not change cached reads
+func copyMarkets(markets []models.Market) []models.Market {
+
+	copied := make([]models.Market, len(markets))
+
+	for i, m := range markets {
+
+		copied[i] = copyMarket(m)
+	}
+
+	return copied
+}
+
+func copyMarket(market models.Market) models.Market {
+
+	market.Outcomes = append([]models.Outcome(nil), market.Outcomes...)
+	return market
+}
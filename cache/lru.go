@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entryKey identifies a cached read, marketID and specifier are empty for GetAllMarkets
+type entryKey struct {
+	producerID int64
+	matchID    int64
+	marketID   int64
+	specifier  string
+	all        bool
+}
+
+type entry struct {
+	key     entryKey
+	value   interface{}
+	expires time.Time
+}
+
+// lru least recently used cache with a TTL per entry, entries are indexed by match so that a match can be invalidated at once
+type lru struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	ll         *list.List
+	entries    map[entryKey]*list.Element
+	matches    map[int64]map[entryKey]struct{}
+
+	// invalidated time of the last invalidation of a match, reads started before it are not cached
+	invalidated map[int64]time.Time
+}
+
+func newLRU(maxEntries int, ttl time.Duration) *lru {
+
+	return &lru{
+		maxEntries:  maxEntries,
+		ttl:         ttl,
+		ll:          list.New(),
+		entries:     make(map[entryKey]*list.Element),
+		matches:     make(map[int64]map[entryKey]struct{}),
+		invalidated: make(map[int64]time.Time),
+	}
+}
+
+// get gets the value of the key, expired is true when the key was found but its TTL has passed
+func (c *lru) get(key entryKey) (value interface{}, ok, expired bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+
+		return nil, false, false
+	}
+
+	e := el.Value.(*entry)
+
+	if time.Now().After(e.expires) {
+
+		c.remove(el)
+		return nil, false, true
+	}
+
+	c.ll.MoveToFront(el)
+
+	return e.value, true, false
+}
+
+// add caches the value read at readStart, returns the number of entries evicted to stay within maxEntries.
+// The value is dropped if the match was invalidated after the read started
+func (c *lru) add(key entryKey, value interface{}, readStart time.Time) (added bool, evicted int) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if at, ok := c.invalidated[key.matchID]; ok && !at.Before(readStart) {
+
+		return false, 0
+	}
+
+	if el, ok := c.entries[key]; ok {
+
+		e := el.Value.(*entry)
+		e.value = value
+		e.expires = time.Now().Add(c.ttl)
+		c.ll.MoveToFront(el)
+		return true, 0
+	}
+
+	el := c.ll.PushFront(&entry{key: key, value: value, expires: time.Now().Add(c.ttl)})
+	c.entries[key] = el
+
+	keys, ok := c.matches[key.matchID]
+	if !ok {
+
+		keys = make(map[entryKey]struct{})
+		c.matches[key.matchID] = keys
+	}
+
+	keys[key] = struct{}{}
+
+	for c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+
+		c.remove(c.ll.Back())
+		evicted++
+	}
+
+	return true, evicted
+}
+
+// invalidate removes the entries of the match, producerID 0 removes the entries of all producers
+func (c *lru) invalidate(matchID, producerID int64) int {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.invalidated[matchID] = now
+
+	// reads older than the TTL are not in flight anymore, forget their invalidation times
+	if len(c.invalidated) > c.maxEntries {
+
+		for id, at := range c.invalidated {
+
+			if now.Sub(at) > c.ttl {
+
+				delete(c.invalidated, id)
+			}
+		}
+	}
+
+	removed := 0
+
+	for key := range c.matches[matchID] {
+
+		if producerID > 0 && key.producerID != producerID {
+
+			continue
+		}
+
+		c.remove(c.entries[key])
+		removed++
+	}
+
+	return removed
+}
+
+// len gets the number of cached entries
+func (c *lru) len() int {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// remove removes the entry, the lock must be held
+func (c *lru) remove(el *list.Element) {
+
+	e := el.Value.(*entry)
+
+	c.ll.Remove(el)
+	delete(c.entries, e.key)
+
+	keys := c.matches[e.key.matchID]
+	delete(keys, e.key)
+
+	if len(keys) == 0 {
+
+		delete(c.matches, e.key.matchID)
+	}
+}
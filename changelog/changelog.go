@@ -0,0 +1,237 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/keyspace"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// DefaultSize number of changes kept per match when FEEDS_CHANGE_LOG_SIZE is not set
+const DefaultSize = 200
+
+// Entry a change of a match, the log keeps the keys of the changed markets and not the markets,
+// changed markets are read from the stored markets when the changes are requested
+type Entry struct {
+
+	// Sequence sequence of the match after the change
+	Sequence int64 `json:"s"`
+
+	// ProducerID producer whose markets changed, 0 for all producers
+	ProducerID int64 `json:"p,omitempty"`
+
+	// Markets keys of the changed markets, see MarketKey
+	Markets []string `json:"m,omitempty"`
+
+	// AllMarkets all markets of the producer changed e.g a bet stop
+	AllMarkets bool `json:"a,omitempty"`
+
+	// Resync the change is not a change of markets e.g a delete, clients read all markets again
+	Resync bool `json:"r,omitempty"`
+
+	// Timestamp time of the change in milliseconds
+	Timestamp int64 `json:"t"`
+}
+
+// Size gets FEEDS_CHANGE_LOG_SIZE, the number of changes kept per match
+func Size() int64 {
+
+	size, _ := strconv.ParseInt(os.Getenv("FEEDS_CHANGE_LOG_SIZE"), 10, 64)
+	if size <= 0 {
+
+		return DefaultSize
+	}
+
+	return size
+}
+
+// MarketKey gets the key of a market in a change, marketID:specifier
+func MarketKey(marketID int64, specifier string) string {
+
+	return fmt.Sprintf("%d:%s", marketID, specifier)
+}
+
+// MarketKeys gets the keys of the markets
+func MarketKeys(markets []models.Market) []string {
+
+	var keys []string
+
+	for _, m := range markets {
+
+		keys = append(keys, MarketKey(m.MarketID, m.Specifier))
+	}
+
+	return keys
+}
+
+// Push adds the change to the change log of the match, the log is capped at Size changes
+// and expires with the sequence of the match
+func Push(ctx context.Context, conn utils.Redis, keys keyspace.Keyspace, matchID int64, entry Entry) {
+
+	if entry.Timestamp == 0 {
+
+		entry.Timestamp = time.Now().UnixMilli()
+	}
+
+	js, _ := json.Marshal(entry)
+
+	err := utils.PushRedisListWithExpiry(ctx, conn, keys.MatchChanges(matchID), string(js), Size(), constants.MatchVersionExpiry)
+	if err != nil {
+
+		log.Printf("error saving change %d of match %d | %s", entry.Sequence, matchID, err.Error())
+	}
+}
+
+// Read gets the changes of the match ordered by sequence
+func Read(ctx context.Context, conn utils.Redis, keys keyspace.Keyspace, matchID int64) []Entry {
+
+	values, err := utils.GetRedisList(ctx, conn, keys.MatchChanges(matchID), 0, -1)
+	if err != nil {
+
+		return nil
+	}
+
+	var entries []Entry
+
+	for _, value := range values {
+
+		var entry Entry
+
+		err = json.Unmarshal([]byte(value), &entry)
+		if err != nil {
+
+			log.Printf("error decoding change %s of match %d | %s", value, matchID, err.Error())
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+
+		return entries[i].Sequence < entries[j].Sequence
+	})
+
+	return entries
+}
+
+// Window changes of a producer between two sequences
+type Window struct {
+
+	// Sequence last sequence covered by the window, changes after it are returned by the next request
+	Sequence int64
+
+	// Markets keys of the markets changed in the window
+	Markets map[string]bool
+
+	// AllMarkets all markets changed in the window
+	AllMarkets bool
+
+	// Resync the window can not be answered with changed markets, the log does not reach back to the requested
+	// sequence or a change in the window is not a change of markets
+	Resync bool
+}
+
+// Since gets the changes of the producer after since up to current, the sequence the stored markets were read at.
+// A change is logged right after its markets are saved, when the next change is not logged yet the window stops
+// before it. A change missing before a logged change was trimmed or failed to log and requires a resync.
+// Changes of other producers are skipped, changes of all producers (producer 0) are included
+func Since(entries []Entry, producerID, since, current int64) Window {
+
+	window := Window{
+		Sequence: since,
+		Markets:  make(map[string]bool),
+	}
+
+	if since <= 0 || since > current || len(entries) == 0 {
+
+		window.Sequence = current
+		window.Resync = since != current
+		return window
+	}
+
+	next := since + 1
+
+	for _, entry := range entries {
+
+		if entry.Sequence < next {
+
+			continue
+		}
+
+		if entry.Sequence > current {
+
+			break
+		}
+
+		if entry.Sequence > next {
+
+			window.Sequence = current
+			window.Resync = true
+			return window
+		}
+
+		next++
+		window.Sequence = entry.Sequence
+
+		if entry.ProducerID != 0 && entry.ProducerID != producerID {
+
+			continue
+		}
+
+		if entry.Resync {
+
+			window.Resync = true
+		}
+
+		if entry.AllMarkets {
+
+			window.AllMarkets = true
+		}
+
+		for _, key := range entry.Markets {
+
+			window.Markets[key] = true
+		}
+	}
+
+	return window
+}
+
+// Changes gets the changes of the markets read at current after since
+func Changes(entries []Entry, matchID, producerID, since, current int64, markets []models.Market) models.MatchChanges {
+
+	window := Since(entries, producerID, since, current)
+
+	changes := models.MatchChanges{
+		MatchID:    matchID,
+		ProducerID: producerID,
+		Sequence:   window.Sequence,
+		FullResync: window.Resync,
+	}
+
+	if window.Resync || window.AllMarkets {
+
+		changes.Markets = markets
+		return changes
+	}
+
+	for _, m := range markets {
+
+		if window.Markets[MarketKey(m.MarketID, m.Specifier)] {
+
+			changes.Markets = append(changes.Markets, m)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,167 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// entry a change of producer 1 at the sequence with the keys of the changed markets
+func entry(sequence int64, markets ...string) Entry {
+
+	return Entry{Sequence: sequence, ProducerID: 1, Markets: markets}
+}
+
+func TestSince(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		entries        []Entry
+		since, current int64
+		want           Window
+	}{
+		{
+			name:    "no change",
+			entries: []Entry{entry(4, "1:"), entry(5, "18:total=2.5")},
+			since:   5, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{}},
+		},
+		{
+			name:    "changed markets",
+			entries: []Entry{entry(3, "1:"), entry(4, "18:total=2.5"), entry(5, "1:", "18:total=3.5")},
+			since:   3, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{"18:total=2.5": true, "1:": true, "18:total=3.5": true}},
+		},
+		{
+			name:    "gap before the first logged change",
+			entries: []Entry{entry(4, "1:"), entry(5, "18:total=2.5")},
+			since:   2, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{}, Resync: true},
+		},
+		{
+			name:    "gap between logged changes",
+			entries: []Entry{entry(3, "1:"), entry(5, "18:total=2.5")},
+			since:   2, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{"1:": true}, Resync: true},
+		},
+		{
+			name:    "next change not logged yet",
+			entries: []Entry{entry(3, "1:"), entry(4, "18:total=2.5")},
+			since:   3, current: 5,
+			want: Window{Sequence: 4, Markets: map[string]bool{"18:total=2.5": true}},
+		},
+		{
+			name:    "only change not logged yet",
+			entries: []Entry{entry(2, "1:"), entry(3, "18:total=2.5")},
+			since:   3, current: 4,
+			want: Window{Sequence: 3, Markets: map[string]bool{}},
+		},
+		{
+			name:    "changes after the read sequence",
+			entries: []Entry{entry(4, "1:"), entry(5, "18:total=2.5"), entry(6, "60:")},
+			since:   3, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{"1:": true, "18:total=2.5": true}},
+		},
+		{
+			name:    "changes of other producers",
+			entries: []Entry{{Sequence: 4, ProducerID: 3, Markets: []string{"1:"}, AllMarkets: true}, entry(5, "18:total=2.5")},
+			since:   3, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{"18:total=2.5": true}},
+		},
+		{
+			name:    "resync of all producers",
+			entries: []Entry{{Sequence: 4, Resync: true}, entry(5, "18:total=2.5")},
+			since:   3, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{"18:total=2.5": true}, Resync: true},
+		},
+		{
+			name:    "all markets of all producers",
+			entries: []Entry{{Sequence: 4, AllMarkets: true}},
+			since:   3, current: 4,
+			want: Window{Sequence: 4, Markets: map[string]bool{}, AllMarkets: true},
+		},
+		{
+			name:    "since after current",
+			entries: []Entry{entry(4, "1:"), entry(5, "18:total=2.5")},
+			since:   7, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{}, Resync: true},
+		},
+		{
+			name:    "no sequence",
+			entries: []Entry{entry(1, "1:")},
+			since:   0, current: 1,
+			want: Window{Sequence: 1, Markets: map[string]bool{}, Resync: true},
+		},
+		{
+			name:  "empty log",
+			since: 3, current: 5,
+			want: Window{Sequence: 5, Markets: map[string]bool{}, Resync: true},
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			got := Since(tt.entries, 1, tt.since, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+
+				t.Fatalf("Since(%d, %d) = %+v, want %+v", tt.since, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+
+	markets := []models.Market{
+		{MarketID: 1},
+		{MarketID: 18, Specifier: "total=2.5"},
+		{MarketID: 18, Specifier: "total=3.5"},
+	}
+
+	tests := []struct {
+		name           string
+		entries        []Entry
+		since, current int64
+		want           models.MatchChanges
+	}{
+		{
+			name:    "changed markets",
+			entries: []Entry{entry(4, "18:total=2.5"), entry(5, "1:")},
+			since:   3, current: 5,
+			want: models.MatchChanges{MatchID: 123, ProducerID: 1, Sequence: 5, Markets: []models.Market{markets[0], markets[1]}},
+		},
+		{
+			name:    "no change",
+			entries: []Entry{entry(5, "1:")},
+			since:   5, current: 5,
+			want: models.MatchChanges{MatchID: 123, ProducerID: 1, Sequence: 5},
+		},
+		{
+			name:    "all markets",
+			entries: []Entry{{Sequence: 4, ProducerID: 1, AllMarkets: true}},
+			since:   3, current: 4,
+			want: models.MatchChanges{MatchID: 123, ProducerID: 1, Sequence: 4, Markets: markets},
+		},
+		{
+			name:    "resync after a gap",
+			entries: []Entry{entry(5, "1:")},
+			since:   3, current: 5,
+			want: models.MatchChanges{MatchID: 123, ProducerID: 1, Sequence: 5, FullResync: true, Markets: markets},
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			got := Changes(tt.entries, 123, 1, tt.since, tt.current, markets)
+			if !reflect.DeepEqual(got, tt.want) {
+
+				t.Fatalf("Changes(%d, %d) = %+v, want %+v", tt.since, tt.current, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/touchvas/odds-sdk/v2/codec"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/snapshot"
+)
+
+// codecbench compares the encoded size and the encode and decode time of the stored markets codecs
+// for a generated football match, or for the matches of a snapshot file
+//
+//	codecbench -markets 350
+//	codecbench -snapshot feeds.jsonl
+func main() {
+
+	markets := flag.Int("markets", 350, "number of markets of the generated match")
+	snapshotPath := flag.String("snapshot", "", "snapshot file whose matches are used instead of a generated match")
+	flag.Parse()
+
+	matches := [][]models.Market{generateMatch(*markets)}
+
+	if len(*snapshotPath) > 0 {
+
+		records, err := readSnapshot(*snapshotPath)
+		if err != nil {
+
+			log.Printf("error reading snapshot %s | %s", *snapshotPath, err.Error())
+			os.Exit(1)
+		}
+
+		matches = records
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "codec\tbytes\tencode ns/op\tencode allocs/op\tdecode ns/op\tdecode allocs/op\t")
+
+	for _, c := range []codec.Codec{codec.JSON, codec.Protobuf, codec.Msgpack} {
+
+		size := 0
+
+		var encoded [][]byte
+
+		for _, m := range matches {
+
+			data, err := c.EncodeMarkets(m)
+			if err != nil {
+
+				log.Printf("error encoding with %s | %s", c.Name(), err.Error())
+				os.Exit(1)
+			}
+
+			size += len(data)
+			encoded = append(encoded, data)
+		}
+
+		encode := testing.Benchmark(func(b *testing.B) {
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+
+				c.EncodeMarkets(matches[i%len(matches)])
+			}
+		})
+
+		decode := testing.Benchmark(func(b *testing.B) {
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+
+				_, err := codec.DecodeMarkets(encoded[i%len(encoded)])
+				if err != nil {
+
+					b.Fatal(err)
+				}
+			}
+		})
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t\n", c.Name(), size/len(matches), encode.NsPerOp(), encode.AllocsPerOp(), decode.NsPerOp(), decode.AllocsPerOp())
+	}
+
+	w.Flush()
+}
+
+// generateMatch generates the markets of a football match, lines of total, handicap and player markets
+// give the several hundred markets of a big match
+func generateMatch(count int) []models.Market {
+
+	r := rand.New(rand.NewSource(1))
+
+	outcomeSets := [][]string{
+		{"1", "2", "3"},
+		{"12", "13"},
+		{"74", "76"},
+		{"1714", "1715"},
+		{"9", "10", "11"},
+	}
+
+	var markets []models.Market
+
+	for i := 0; i < count; i++ {
+
+		marketID := int64(1 + i%60)
+		outcomes := outcomeSets[i%len(outcomeSets)]
+
+		m := models.Market{
+			MarketName: fmt.Sprintf("Market %d", marketID),
+			MarketID:   marketID,
+			Specifier:  fmt.Sprintf("total=%.1f", 0.5+float64(i/60)),
+			StatusName: "Active",
+		}
+
+		for _, id := range outcomes {
+
+			odds := 1.01 + r.Float64()*9
+			m.Outcomes = append(m.Outcomes, models.Outcome{
+				OutcomeName: fmt.Sprintf("Outcome %s", id),
+				OutcomeID:   id,
+				Odds:        float64(int(odds*100)) / 100,
+				Active:      1,
+				Probability: 1 / odds,
+			})
+		}
+
+		markets = append(markets, m)
+	}
+
+	return markets
+}
+
+// readSnapshot gets the markets of every record of a snapshot file
+func readSnapshot(path string) ([][]models.Market, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var matches [][]models.Market
+
+	err = snapshot.Scan(f, func(record snapshot.Record) error {
+
+		if len(record.Markets) > 0 {
+
+			matches = append(matches, record.Markets)
+		}
+
+		return nil
+	})
+	if err != nil {
+
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+
+		return nil, fmt.Errorf("no markets in snapshot")
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/touchvas/odds-sdk/v2/cmd/internal/cli"
+	"github.com/touchvas/odds-sdk/v2/migrate"
+)
+
+// feedmigrate copies all matches from one feed backend to the other and verifies the copied counts
+//
+//	feedmigrate -from redis -to mysql
+//	feedmigrate -from mysql -to redis -matches 123,456
+func main() {
+
+	from := flag.String("from", "", "source feed backend, redis or mysql")
+	to := flag.String("to", "", "destination feed backend, redis or mysql")
+	matches := flag.String("matches", "", "comma separated matchIDs to copy, copies all matches when empty")
+	flag.Parse()
+
+	if len(*from) == 0 || len(*to) == 0 || *from == *to {
+
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	report := migrate.Copy(cli.Feed(*from), cli.Feed(*to), cli.MustParseMatchIDs(*matches)...)
+
+	js, _ := json.MarshalIndent(report, "", "  ")
+	log.Printf("%s", string(js))
+
+	if len(report.Mismatches) > 0 {
+
+		os.Exit(1)
+	}
+}
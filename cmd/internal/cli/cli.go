@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/feeds/mysqlfeeds"
+	"github.com/touchvas/odds-sdk/v2/feeds/redisfeed"
+)
+
+// Feed gets the feed instance of the backend, redis or mysql, exits on an unknown backend
+func Feed(backend string) feeds.Feed {
+
+	switch backend {
+
+	case "redis":
+		return redisfeed.GetFeedsInstance()
+
+	case "mysql":
+		return mysqlfeeds.GetFeedsInstance()
+
+	}
+
+	log.Fatalf("unknown backend %s", backend)
+	return nil
+}
+
+// ParseMatchIDs parses comma separated matchIDs, an empty string is no matchIDs. Any invalid matchID is an error
+// so a typo never selects all matches
+func ParseMatchIDs(matches string) ([]int64, error) {
+
+	if len(strings.TrimSpace(matches)) == 0 {
+
+		return nil, nil
+	}
+
+	var matchIDs []int64
+
+	for _, m := range strings.Split(matches, ",") {
+
+		matchID, err := strconv.ParseInt(strings.TrimSpace(m), 10, 64)
+		if err != nil || matchID <= 0 {
+
+			return nil, fmt.Errorf("invalid matchID %q in %s", m, matches)
+		}
+
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	return matchIDs, nil
+}
+
+// MustParseMatchIDs parses comma separated matchIDs, exits on an invalid matchID
+func MustParseMatchIDs(matches string) []int64 {
+
+	matchIDs, err := ParseMatchIDs(matches)
+	if err != nil {
+
+		log.Fatalf("%s", err.Error())
+	}
+
+	return matchIDs
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/touchvas/odds-sdk/v2/cmd/internal/cli"
+	"github.com/touchvas/odds-sdk/v2/consumer"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// oddsconsumer applies the feeds messages of the JetStream stream to a feed backend
+//
+//	oddsconsumer -backend redis
+func main() {
+
+	backend := flag.String("backend", "redis", "feed backend, redis or mysql")
+	flag.Parse()
+
+	c, err := consumer.New(cli.Feed(*backend), utils.GetNatsConnection(), consumer.ConfigFromEnv())
+	if err != nil {
+
+		log.Fatalf("error creating consumer %s", err.Error())
+	}
+
+	err = c.Start()
+	if err != nil {
+
+		log.Fatalf("error starting consumer %s", err.Error())
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+
+	c.Stop()
+	log.Printf("consumer stopped %+v", c.Stats())
+}
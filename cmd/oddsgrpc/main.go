@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/feeds/mysqlfeeds"
+	"github.com/touchvas/odds-sdk/v2/feeds/redisfeed"
+	"github.com/touchvas/odds-sdk/v2/grpcserver"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// oddsgrpc serves the odds of a feed backend over grpc
+//
+//	oddsgrpc -backend redis -addr :9090
+func main() {
+
+	backend := flag.String("backend", "redis", "feed backend, redis or mysql")
+	addr := flag.String("addr", ":9090", "address the grpc server listens on")
+	flag.Parse()
+
+	server := newServer(*backend)
+
+	go func() {
+
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+
+		server.Shutdown()
+	}()
+
+	err := server.ListenAndServe(*addr)
+	if err != nil {
+
+		log.Fatalf("error serving odds grpc %s", err.Error())
+	}
+}
+
+func newServer(backend string) *grpcserver.Server {
+
+	switch backend {
+
+	case "redis":
+		rf := redisfeed.GetFeedsInstance()
+		server := grpcserver.New(rf, utils.GetNatsConnection())
+		server.WithContext = func(ctx context.Context) feeds.Feed {
+
+			return rf.WithContext(ctx)
+		}
+
+		return server
+
+	case "mysql":
+		mf := mysqlfeeds.GetFeedsInstance()
+		server := grpcserver.New(mf, utils.GetNatsConnection())
+		server.WithContext = func(ctx context.Context) feeds.Feed {
+
+			return mf.WithContext(ctx)
+		}
+
+		return server
+
+	}
+
+	log.Fatalf("unknown backend %s", backend)
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/touchvas/odds-sdk/v2/cmd/internal/cli"
+	"github.com/touchvas/odds-sdk/v2/feeds/redisfeed"
+)
+
+// redischeck reports inconsistencies between the match blob, market-keys list and market keys of the namespace
+//
+//	redischeck
+//	redischeck -repair -matches 123,456
+func main() {
+
+	repair := flag.Bool("repair", false, "repair the inconsistencies found")
+	matches := flag.String("matches", "", "comma separated matchIDs to check, checks all matches when empty")
+	flag.Parse()
+
+	report := redisfeed.GetFeedsInstance().CheckConsistency(*repair, cli.MustParseMatchIDs(*matches)...)
+
+	js, _ := json.MarshalIndent(report, "", "  ")
+	log.Printf("%s", string(js))
+
+	if report.Unrepaired() > 0 {
+
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/touchvas/odds-sdk/v2/cmd/internal/cli"
+	"github.com/touchvas/odds-sdk/v2/snapshot"
+)
+
+// snapshot exports feed state of a namespace to a JSON lines file or imports it back
+//
+//	snapshot -backend redis -export snapshot.jsonl -matches 123,456
+//	snapshot -backend mysql -import snapshot.jsonl
+func main() {
+
+	backend := flag.String("backend", "redis", "feed backend, redis or mysql")
+	exportPath := flag.String("export", "", "file to export the snapshot to")
+	importPath := flag.String("import", "", "file to import the snapshot from")
+	matches := flag.String("matches", "", "comma separated matchIDs to export, exports all matches when empty")
+	flag.Parse()
+
+	if (len(*exportPath) == 0) == (len(*importPath) == 0) {
+
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	feed := cli.Feed(*backend)
+
+	if len(*exportPath) > 0 {
+
+		total, err := snapshot.ExportFile(feed, *exportPath, cli.MustParseMatchIDs(*matches)...)
+		if err != nil {
+
+			log.Fatalf("error exporting snapshot to %s | %s", *exportPath, err.Error())
+		}
+
+		log.Printf("exported %d records to %s", total, *exportPath)
+		return
+	}
+
+	total, err := snapshot.ImportFile(feed, *importPath)
+	if err != nil {
+
+		log.Fatalf("error importing snapshot from %s | %s", *importPath, err.Error())
+	}
+
+	log.Printf("imported %d records from %s", total, *importPath)
+}
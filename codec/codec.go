@@ -0,0 +1,143 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// VersionProtobuf first byte of markets encoded with the protobuf codec
+const VersionProtobuf byte = 2
+
+// VersionMsgpack first byte of markets encoded with the msgpack codec
+const VersionMsgpack byte = 3
+
+// Codec encodes stored markets. Binary codecs prefix the encoded markets with their version byte, JSON is stored
+// without a version byte as in previous releases so that older readers keep working while a deployment is rolled out.
+// Decode and DecodeMarket read markets encoded by any codec, deploy readers before switching writers to a binary codec
+type Codec interface {
+
+	// Name gets the name of the codec, json, protobuf or msgpack
+	Name() string
+
+	// EncodeMarkets encodes the markets of a match including the version byte
+	EncodeMarkets(markets []models.Market) ([]byte, error)
+
+	// EncodeMarket encodes a market including the version byte
+	EncodeMarket(market models.Market) ([]byte, error)
+}
+
+// JSON codec of previous releases
+var JSON Codec = jsonCodec{}
+
+// Protobuf codec, markets are encoded as a MarketList message of Market and Outcome messages
+var Protobuf Codec = protobufCodec{}
+
+// Msgpack codec, fields are named as in JSON
+var Msgpack Codec = msgpackCodec{}
+
+// Codecs all codecs by name
+var Codecs = map[string]Codec{
+	JSON.Name():     JSON,
+	Protobuf.Name(): Protobuf,
+	Msgpack.Name():  Msgpack,
+}
+
+// ByName gets the codec with the supplied name, json when the name is empty
+func ByName(name string) (Codec, error) {
+
+	if len(name) == 0 {
+
+		return JSON, nil
+	}
+
+	c, ok := Codecs[strings.ToLower(name)]
+	if !ok {
+
+		return nil, fmt.Errorf("unknown codec %s", name)
+	}
+
+	return c, nil
+}
+
+// FromEnv gets the codec set in FEEDS_CODEC, json when not set or unknown
+func FromEnv() Codec {
+
+	c, err := ByName(os.Getenv("FEEDS_CODEC"))
+	if err != nil {
+
+		return JSON
+	}
+
+	return c
+}
+
+// DecodeMarkets decodes the markets of a match encoded by any codec
+func DecodeMarkets(data []byte) ([]models.Market, error) {
+
+	if len(data) == 0 {
+
+		return nil, fmt.Errorf("no encoded markets")
+	}
+
+	switch data[0] {
+
+	case VersionProtobuf:
+		return decodeProtobufMarkets(data[1:])
+
+	case VersionMsgpack:
+		return decodeMsgpackMarkets(data[1:])
+
+	}
+
+	var markets []models.Market
+
+	err := json.Unmarshal(data, &markets)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return markets, nil
+}
+
+// DecodeMarket decodes a market encoded by any codec
+func DecodeMarket(data []byte, market *models.Market) error {
+
+	if len(data) == 0 {
+
+		return fmt.Errorf("no encoded market")
+	}
+
+	switch data[0] {
+
+	case VersionProtobuf:
+		return decodeProtobufMarket(data[1:], market)
+
+	case VersionMsgpack:
+		return decodeMsgpackMarket(data[1:], market)
+
+	}
+
+	return json.Unmarshal(data, market)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+
+	return "json"
+}
+
+func (jsonCodec) EncodeMarkets(markets []models.Market) ([]byte, error) {
+
+	return json.Marshal(markets)
+}
+
+func (jsonCodec) EncodeMarket(market models.Market) ([]byte, error) {
+
+	return json.Marshal(market)
+}
@@ -0,0 +1,246 @@
+package codec
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+var codecs = []Codec{JSON, Protobuf, Msgpack}
+
+// match generates the markets of a big football match, lines of total, handicap and player markets
+func match(count int) []models.Market {
+
+	r := rand.New(rand.NewSource(1))
+
+	outcomeSets := [][]string{
+		{"1", "2", "3"},
+		{"12", "13"},
+		{"74", "76"},
+		{"1714", "1715"},
+		{"9", "10", "11"},
+	}
+
+	var markets []models.Market
+
+	for i := 0; i < count; i++ {
+
+		marketID := int64(1 + i%60)
+
+		m := models.Market{
+			MarketURL:  fmt.Sprintf("/markets/%d", marketID),
+			MarketName: fmt.Sprintf("Market %d", marketID),
+			MarketID:   marketID,
+			Specifier:  fmt.Sprintf("total=%.1f", 0.5+float64(i/60)),
+			StatusName: "Active",
+			Status:     int64(i % 2),
+		}
+
+		for _, id := range outcomeSets[i%len(outcomeSets)] {
+
+			odds := 1.01 + r.Float64()*9
+			m.Outcomes = append(m.Outcomes, models.Outcome{
+				OutcomeName: fmt.Sprintf("Outcome %s", id),
+				OutcomeID:   id,
+				Odds:        float64(int(odds*100)) / 100,
+				Active:      int64(i % 2),
+				Probability: 1 / odds,
+			})
+		}
+
+		markets = append(markets, m)
+	}
+
+	return markets
+}
+
+func TestRoundTrip(t *testing.T) {
+
+	markets := match(350)
+
+	for _, c := range codecs {
+
+		t.Run(c.Name(), func(t *testing.T) {
+
+			data, err := c.EncodeMarkets(markets)
+			if err != nil {
+
+				t.Fatalf("EncodeMarkets: %s", err.Error())
+			}
+
+			decoded, err := DecodeMarkets(data)
+			if err != nil {
+
+				t.Fatalf("DecodeMarkets: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(decoded, markets) {
+
+				t.Fatalf("decoded markets differ from the encoded markets")
+			}
+
+			for _, m := range markets[:10] {
+
+				data, err := c.EncodeMarket(m)
+				if err != nil {
+
+					t.Fatalf("EncodeMarket: %s", err.Error())
+				}
+
+				var market models.Market
+
+				err = DecodeMarket(data, &market)
+				if err != nil {
+
+					t.Fatalf("DecodeMarket: %s", err.Error())
+				}
+
+				if !reflect.DeepEqual(market, m) {
+
+					t.Fatalf("decoded market %d %s differs from the encoded market", m.MarketID, m.Specifier)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeVersions(t *testing.T) {
+
+	markets := match(5)
+
+	versions := map[string]byte{
+		JSON.Name():     '[',
+		Protobuf.Name(): VersionProtobuf,
+		Msgpack.Name():  VersionMsgpack,
+	}
+
+	// a reader decodes markets written by every codec, whichever codec it writes with
+	for _, c := range codecs {
+
+		data, err := c.EncodeMarkets(markets)
+		if err != nil {
+
+			t.Fatalf("%s EncodeMarkets: %s", c.Name(), err.Error())
+		}
+
+		if data[0] != versions[c.Name()] {
+
+			t.Errorf("%s markets start with %d, want %d", c.Name(), data[0], versions[c.Name()])
+		}
+
+		decoded, err := DecodeMarkets(data)
+		if err != nil {
+
+			t.Fatalf("%s DecodeMarkets: %s", c.Name(), err.Error())
+		}
+
+		if !reflect.DeepEqual(decoded, markets) {
+
+			t.Errorf("%s decoded markets differ from the encoded markets", c.Name())
+		}
+
+		data, err = c.EncodeMarket(markets[0])
+		if err != nil {
+
+			t.Fatalf("%s EncodeMarket: %s", c.Name(), err.Error())
+		}
+
+		var market models.Market
+
+		err = DecodeMarket(data, &market)
+		if err != nil {
+
+			t.Fatalf("%s DecodeMarket: %s", c.Name(), err.Error())
+		}
+
+		if !reflect.DeepEqual(market, markets[0]) {
+
+			t.Errorf("%s decoded market differs from the encoded market", c.Name())
+		}
+	}
+
+	if _, err := DecodeMarkets(nil); err == nil {
+
+		t.Errorf("DecodeMarkets decoded no data")
+	}
+
+	if err := DecodeMarket(nil, new(models.Market)); err == nil {
+
+		t.Errorf("DecodeMarket decoded no data")
+	}
+}
+
+func TestByName(t *testing.T) {
+
+	for _, name := range []string{"json", "protobuf", "msgpack", "MSGPACK"} {
+
+		if _, err := ByName(name); err != nil {
+
+			t.Errorf("ByName(%s): %s", name, err.Error())
+		}
+	}
+
+	if c, err := ByName(""); err != nil || c != JSON {
+
+		t.Errorf("ByName(\"\") is not the json codec")
+	}
+
+	if _, err := ByName("xml"); err == nil {
+
+		t.Errorf("ByName(xml) found a codec")
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+
+	markets := match(350)
+
+	for _, c := range codecs {
+
+		b.Run(c.Name(), func(b *testing.B) {
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+
+				_, err := c.EncodeMarkets(markets)
+				if err != nil {
+
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+
+	markets := match(350)
+
+	for _, c := range codecs {
+
+		data, err := c.EncodeMarkets(markets)
+		if err != nil {
+
+			b.Fatal(err)
+		}
+
+		b.Run(c.Name(), func(b *testing.B) {
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+
+			for i := 0; i < b.N; i++ {
+
+				_, err := DecodeMarkets(data)
+				if err != nil {
+
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
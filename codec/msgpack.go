@@ -0,0 +1,70 @@
+package codec
+
+import (
+	"bytes"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string {
+
+	return "msgpack"
+}
+
+func (msgpackCodec) EncodeMarkets(markets []models.Market) ([]byte, error) {
+
+	return encodeMsgpack(markets)
+}
+
+func (msgpackCodec) EncodeMarket(market models.Market) ([]byte, error) {
+
+	return encodeMsgpack(market)
+}
+
+// encodeMsgpack encodes v with the version byte, fields are named by their json tags
+func encodeMsgpack(v interface{}) ([]byte, error) {
+
+	var buf bytes.Buffer
+	buf.WriteByte(VersionMsgpack)
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	enc.UseCompactFloats(true)
+
+	err := enc.Encode(v)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeMsgpackMarkets(b []byte) ([]models.Market, error) {
+
+	markets := []models.Market{}
+
+	err := newMsgpackDecoder(b).Decode(&markets)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return markets, nil
+}
+
+func decodeMsgpackMarket(b []byte, market *models.Market) error {
+
+	return newMsgpackDecoder(b).Decode(market)
+}
+
+func newMsgpackDecoder(b []byte) *msgpack.Decoder {
+
+	dec := msgpack.NewDecoder(bytes.NewReader(b))
+	dec.SetCustomStructTag("json")
+	return dec
+}
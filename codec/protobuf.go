@@ -0,0 +1,304 @@
+package codec
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// field numbers of the Market, Outcome and MarketList messages
+const (
+	marketURLField        protowire.Number = 1
+	marketNameField       protowire.Number = 2
+	marketIDField         protowire.Number = 3
+	marketSpecifierField  protowire.Number = 4
+	marketStatusNameField protowire.Number = 5
+	marketStatusField     protowire.Number = 6
+	marketOutcomesField   protowire.Number = 7
+
+	outcomeNameField          protowire.Number = 1
+	outcomeIDField            protowire.Number = 2
+	outcomeOddsField          protowire.Number = 3
+	outcomeActiveField        protowire.Number = 4
+	outcomeProbabilityField   protowire.Number = 5
+	outcomeFormattedOddsField protowire.Number = 6
+
+	marketListMarketsField protowire.Number = 1
+)
+
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string {
+
+	return "protobuf"
+}
+
+func (protobufCodec) EncodeMarkets(markets []models.Market) ([]byte, error) {
+
+	b := []byte{VersionProtobuf}
+
+	for _, m := range markets {
+
+		b = protowire.AppendTag(b, marketListMarketsField, protowire.BytesType)
+		b = protowire.AppendBytes(b, appendMarket(nil, m))
+	}
+
+	return b, nil
+}
+
+func (protobufCodec) EncodeMarket(market models.Market) ([]byte, error) {
+
+	return appendMarket([]byte{VersionProtobuf}, market), nil
+}
+
+// appendMarket appends the Market message, zero values are not written as in proto3
+func appendMarket(b []byte, m models.Market) []byte {
+
+	b = appendString(b, marketURLField, m.MarketURL)
+	b = appendString(b, marketNameField, m.MarketName)
+	b = appendInt(b, marketIDField, m.MarketID)
+	b = appendString(b, marketSpecifierField, m.Specifier)
+	b = appendString(b, marketStatusNameField, m.StatusName)
+	b = appendInt(b, marketStatusField, m.Status)
+
+	for _, o := range m.Outcomes {
+
+		b = protowire.AppendTag(b, marketOutcomesField, protowire.BytesType)
+		b = protowire.AppendBytes(b, appendOutcome(nil, o))
+	}
+
+	return b
+}
+
+// appendOutcome appends the Outcome message
+func appendOutcome(b []byte, o models.Outcome) []byte {
+
+	b = appendString(b, outcomeNameField, o.OutcomeName)
+	b = appendString(b, outcomeIDField, o.OutcomeID)
+	b = appendDouble(b, outcomeOddsField, o.Odds)
+	b = appendInt(b, outcomeActiveField, o.Active)
+	b = appendDouble(b, outcomeProbabilityField, o.Probability)
+	b = appendString(b, outcomeFormattedOddsField, o.FormattedOdds)
+
+	return b
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+
+	if len(v) == 0 {
+
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendInt(b []byte, num protowire.Number, v int64) []byte {
+
+	if v == 0 {
+
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+
+	if v == 0 {
+
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func decodeProtobufMarkets(b []byte) ([]models.Market, error) {
+
+	markets := []models.Market{}
+
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+
+		if num != marketListMarketsField || typ != protowire.BytesType {
+
+			return skipField(num, typ, b)
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+
+			return n, protowire.ParseError(n)
+		}
+
+		var m models.Market
+
+		err := decodeProtobufMarket(v, &m)
+		if err != nil {
+
+			return n, err
+		}
+
+		markets = append(markets, m)
+
+		return n, nil
+	})
+
+	return markets, err
+}
+
+func decodeProtobufMarket(b []byte, m *models.Market) error {
+
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+
+		switch {
+
+		case num == marketURLField && typ == protowire.BytesType:
+			return consumeString(b, &m.MarketURL)
+
+		case num == marketNameField && typ == protowire.BytesType:
+			return consumeString(b, &m.MarketName)
+
+		case num == marketIDField && typ == protowire.VarintType:
+			return consumeInt(b, &m.MarketID)
+
+		case num == marketSpecifierField && typ == protowire.BytesType:
+			return consumeString(b, &m.Specifier)
+
+		case num == marketStatusNameField && typ == protowire.BytesType:
+			return consumeString(b, &m.StatusName)
+
+		case num == marketStatusField && typ == protowire.VarintType:
+			return consumeInt(b, &m.Status)
+
+		case num == marketOutcomesField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+
+				return n, protowire.ParseError(n)
+			}
+
+			var o models.Outcome
+
+			err := decodeProtobufOutcome(v, &o)
+			if err != nil {
+
+				return n, err
+			}
+
+			m.Outcomes = append(m.Outcomes, o)
+
+			return n, nil
+
+		}
+
+		return skipField(num, typ, b)
+	})
+}
+
+func decodeProtobufOutcome(b []byte, o *models.Outcome) error {
+
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+
+		switch {
+
+		case num == outcomeNameField && typ == protowire.BytesType:
+			return consumeString(b, &o.OutcomeName)
+
+		case num == outcomeIDField && typ == protowire.BytesType:
+			return consumeString(b, &o.OutcomeID)
+
+		case num == outcomeOddsField && typ == protowire.Fixed64Type:
+			return consumeDouble(b, &o.Odds)
+
+		case num == outcomeActiveField && typ == protowire.VarintType:
+			return consumeInt(b, &o.Active)
+
+		case num == outcomeProbabilityField && typ == protowire.Fixed64Type:
+			return consumeDouble(b, &o.Probability)
+
+		case num == outcomeFormattedOddsField && typ == protowire.BytesType:
+			return consumeString(b, &o.FormattedOdds)
+
+		}
+
+		return skipField(num, typ, b)
+	})
+}
+
+// consumeFields calls fn with the value of every field of the message, fn returns the length of the value
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+
+	for len(b) > 0 {
+
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+
+			return fmt.Errorf("invalid protobuf tag | %s", protowire.ParseError(n))
+		}
+
+		b = b[n:]
+
+		n, err := fn(num, typ, b)
+		if err != nil {
+
+			return fmt.Errorf("invalid protobuf field %d | %s", num, err)
+		}
+
+		b = b[n:]
+	}
+
+	return nil
+}
+
+// skipField skips fields added by newer writers
+func skipField(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+
+	n := protowire.ConsumeFieldValue(num, typ, b)
+	if n < 0 {
+
+		return n, protowire.ParseError(n)
+	}
+
+	return n, nil
+}
+
+func consumeString(b []byte, v *string) (int, error) {
+
+	s, n := protowire.ConsumeString(b)
+	if n < 0 {
+
+		return n, protowire.ParseError(n)
+	}
+
+	*v = s
+	return n, nil
+}
+
+func consumeInt(b []byte, v *int64) (int, error) {
+
+	i, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+
+		return n, protowire.ParseError(n)
+	}
+
+	*v = int64(i)
+	return n, nil
+}
+
+func consumeDouble(b []byte, v *float64) (int, error) {
+
+	i, n := protowire.ConsumeFixed64(b)
+	if n < 0 {
+
+		return n, protowire.ParseError(n)
+	}
+
+	*v = math.Float64frombits(i)
+	return n, nil
+}
@@ -6,3 +6,17 @@ const KeyTemplate = "%s:%d"
 const KeysFieldTemplate = "%s:market-keys"
 const ProducerTemplate = "match-active-producer:%d"
 const EmptySpecifier = "no-specifier"
+const MarketTranslationTemplate = "translation:market:%d:%s"
+const OutcomeTranslationTemplate = "translation:outcome:%d:%s:%s"
+
+// TranslationsVersionKey key of the version of the translations, incremented by every translation change
+const TranslationsVersionKey = "translation:version"
+const SuspendedMarketStatus = -1
+const SuspendedMarketStatusName = "suspended"
+const OverridesTemplate = "overrides:%d"
+const OverridesAuditTemplate = "overrides-audit:%d"
+const OddsInvalidationTopic = "odds_invalidation"
+const MatchVersionExpiry = 7 * 24 * 3600
+const OddsChangeTopic = "odds_change"
+const BetStopTopic = "bet_stop"
+const DeadLetterTopic = "dead_letter"
@@ -0,0 +1,128 @@
+package consumer
+
+import (
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// delivery a decoded message and the jetstream message acknowledged once it is applied
+type delivery struct {
+	msg     jetstream.Msg
+	message Message
+}
+
+// write one write to the feed and the deliveries it applies
+type write struct {
+	message    Message
+	deliveries []delivery
+
+	// index position of each market in the merged OddsChange by market key
+	index map[string]int
+}
+
+// coalesce merges consecutive OddsChange messages of the same producer into one write, the latest version of
+// a market wins. A BetStop is a barrier, it is written on its own between the changes before and after it, and so
+// is a change of producer
+func coalesce(deliveries []delivery) []*write {
+
+	var writes []*write
+
+	for _, d := range deliveries {
+
+		if n := len(writes); n > 0 && writes[n-1].mergeable(d.message) {
+
+			writes[n-1].merge(d)
+			continue
+		}
+
+		writes = append(writes, &write{message: d.message, deliveries: []delivery{d}})
+	}
+
+	return writes
+}
+
+func (w *write) mergeable(message Message) bool {
+
+	return w.message.OddsChange != nil && message.OddsChange != nil && w.message.OddsChange.ProducerID == message.OddsChange.ProducerID
+}
+
+// merge adds the markets of the OddsChange of d to the write, the other fields are taken from the latest message
+// except the arrival time of the first message, so the processing time logged by the feed includes the backlog
+func (w *write) merge(d delivery) {
+
+	merged := w.message.OddsChange
+
+	if w.index == nil {
+
+		// the first message is copied, its markets are not changed in place
+		first := *merged
+		first.Markets = append([]models.Market(nil), merged.Markets...)
+		merged = &first
+
+		w.index = make(map[string]int)
+
+		for i, m := range merged.Markets {
+
+			w.index[changelog.MarketKey(m.MarketID, m.Specifier)] = i
+		}
+	}
+
+	next := *d.message.OddsChange
+	markets := merged.Markets
+
+	for _, m := range next.Markets {
+
+		key := changelog.MarketKey(m.MarketID, m.Specifier)
+
+		if i, ok := w.index[key]; ok {
+
+			markets[i] = m
+			continue
+		}
+
+		w.index[key] = len(markets)
+		markets = append(markets, m)
+	}
+
+	next.Markets = markets
+	next.ConsumerArrivalTime = merged.ConsumerArrivalTime
+
+	w.message = Message{OddsChange: &next}
+	w.deliveries = append(w.deliveries, d)
+}
+
+// source gets the match and producer of the messages of the write, merged messages share the producer
+func (w *write) source() source {
+
+	return source{matchID: w.message.MatchID(), producerID: w.message.ProducerID()}
+}
+
+// timestamp gets the latest timestamp of the messages of the write
+func (w *write) timestamp() int64 {
+
+	var latest int64
+
+	for _, d := range w.deliveries {
+
+		if t := d.message.Timestamp(); t > latest {
+
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// timestamps gets the timestamps of the messages of the write
+func (w *write) timestamps() []int64 {
+
+	timestamps := make([]int64, 0, len(w.deliveries))
+
+	for _, d := range w.deliveries {
+
+		timestamps = append(timestamps, d.message.Timestamp())
+	}
+
+	return timestamps
+}
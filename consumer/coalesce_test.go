@@ -0,0 +1,184 @@
+package consumer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// market a market with one outcome at the supplied odds
+func market(marketID int64, specifier string, odds float64) models.Market {
+
+	return models.Market{
+		MarketID:  marketID,
+		Specifier: specifier,
+		Outcomes:  []models.Outcome{{OutcomeID: "1", Odds: odds}},
+	}
+}
+
+func oddsChange(producerID, timestamp int64, markets ...models.Market) delivery {
+
+	return delivery{message: Message{OddsChange: &models.OddsChange{
+		ProducerID:          producerID,
+		MatchID:             123,
+		BetradarTimestamp:   timestamp,
+		ConsumerArrivalTime: timestamp,
+		Markets:             markets,
+	}}}
+}
+
+func betStop(producerID, timestamp int64) delivery {
+
+	return delivery{message: Message{BetStop: &models.BetStop{
+		ProducerID:        producerID,
+		MatchID:           123,
+		BetradarTimestamp: timestamp,
+	}}}
+}
+
+// describe lists a write as its type, producer, deliveries and markets in order with their odds
+func describe(w *write) []string {
+
+	if w.message.BetStop != nil {
+
+		return []string{fmt.Sprintf("BS producer %d deliveries %d", w.message.ProducerID(), len(w.deliveries))}
+	}
+
+	lines := []string{fmt.Sprintf("OC producer %d deliveries %d", w.message.ProducerID(), len(w.deliveries))}
+
+	for _, m := range w.message.OddsChange.Markets {
+
+		lines = append(lines, fmt.Sprintf("%d %s %.2f", m.MarketID, m.Specifier, m.Outcomes[0].Odds))
+	}
+
+	return lines
+}
+
+func TestCoalesce(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		deliveries []delivery
+		want       [][]string
+	}{
+		{
+			name: "odds changes merged around a bet stop",
+			deliveries: []delivery{
+				oddsChange(1, 1, market(1, "", 1.5)),
+				oddsChange(1, 2, market(18, "total=2.5", 1.9)),
+				betStop(1, 3),
+				oddsChange(1, 4, market(1, "", 1.6)),
+			},
+			want: [][]string{
+				{"OC producer 1 deliveries 2", "1  1.50", "18 total=2.5 1.90"},
+				{"BS producer 1 deliveries 1"},
+				{"OC producer 1 deliveries 1", "1  1.60"},
+			},
+		},
+		{
+			name: "producer switch",
+			deliveries: []delivery{
+				oddsChange(3, 1, market(1, "", 1.5)),
+				oddsChange(1, 2, market(1, "", 1.7)),
+				oddsChange(1, 3, market(18, "total=2.5", 1.9)),
+				oddsChange(3, 4, market(18, "total=2.5", 2.1)),
+			},
+			want: [][]string{
+				{"OC producer 3 deliveries 1", "1  1.50"},
+				{"OC producer 1 deliveries 2", "1  1.70", "18 total=2.5 1.90"},
+				{"OC producer 3 deliveries 1", "18 total=2.5 2.10"},
+			},
+		},
+		{
+			name: "repeated market key keeps its position and the latest version",
+			deliveries: []delivery{
+				oddsChange(1, 1, market(1, "", 1.5), market(18, "total=2.5", 1.9)),
+				oddsChange(1, 2, market(18, "total=3.5", 2.4)),
+				oddsChange(1, 3, market(1, "", 1.4), market(18, "total=2.5", 1.8)),
+			},
+			want: [][]string{
+				{"OC producer 1 deliveries 3", "1  1.40", "18 total=2.5 1.80", "18 total=3.5 2.40"},
+			},
+		},
+		{
+			name: "bet stops are not merged",
+			deliveries: []delivery{
+				betStop(1, 1),
+				betStop(1, 2),
+			},
+			want: [][]string{
+				{"BS producer 1 deliveries 1"},
+				{"BS producer 1 deliveries 1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			var got [][]string
+
+			for _, w := range coalesce(tt.deliveries) {
+
+				got = append(got, describe(w))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+
+				t.Fatalf("coalesce = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoalesceKeepsFirstMessage(t *testing.T) {
+
+	// spare capacity so an append to the markets of the first message would write into its backing array
+	markets := make([]models.Market, 0, 8)
+	markets = append(markets, market(1, "", 1.5), market(18, "total=2.5", 1.9))
+
+	first := oddsChange(1, 1, markets...)
+
+	want := append([]models.Market(nil), markets...)
+
+	writes := coalesce([]delivery{
+		first,
+		oddsChange(1, 2, market(1, "", 1.4)),
+		oddsChange(1, 3, market(18, "total=3.5", 2.4)),
+	})
+
+	if len(writes) != 1 {
+
+		t.Fatalf("coalesce wrote %d times, want 1", len(writes))
+	}
+
+	if !reflect.DeepEqual(first.message.OddsChange.Markets, want) {
+
+		t.Errorf("markets of the first message changed to %v", first.message.OddsChange.Markets)
+	}
+
+	if spare := markets[:3]; spare[2].MarketID != 0 {
+
+		t.Errorf("market %d appended to the markets of the first message", spare[2].MarketID)
+	}
+
+	merged := writes[0].message.OddsChange
+
+	if merged.ConsumerArrivalTime != 1 {
+
+		t.Errorf("merged arrival time %d, want the arrival time 1 of the first message", merged.ConsumerArrivalTime)
+	}
+
+	if merged.BetradarTimestamp != 3 || writes[0].timestamp() != 3 {
+
+		t.Errorf("merged timestamp %d, write timestamp %d, want 3", merged.BetradarTimestamp, writes[0].timestamp())
+	}
+
+	if !reflect.DeepEqual(writes[0].timestamps(), []int64{1, 2, 3}) {
+
+		t.Errorf("write timestamps %v, want [1 2 3]", writes[0].timestamps())
+	}
+}
@@ -0,0 +1,552 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/dispatch"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// DefaultStream stream of the feeds messages when FEEDS_JS_STREAM is not set
+const DefaultStream = "FEEDS"
+
+// DefaultDurable durable consumer name when FEEDS_JS_DURABLE is not set
+const DefaultDurable = "odds-sdk"
+
+// DefaultMaxDeliver deliveries of a message before it is dead lettered when FEEDS_JS_MAX_DELIVER is not set
+const DefaultMaxDeliver = 5
+
+// DefaultAckWait time to apply a message before it is redelivered when FEEDS_JS_ACK_WAIT_MS is not set
+const DefaultAckWait = 30 * time.Second
+
+// DefaultRetryDelay delay of the first redelivery of a failed message when FEEDS_JS_RETRY_DELAY_MS is not set,
+// the delay grows with every delivery
+const DefaultRetryDelay = time.Second
+
+// DefaultWorkers workers applying messages when FEEDS_JS_WORKERS is not set
+const DefaultWorkers = 1
+
+// DefaultOrderWindow time the last applied message of a match is remembered to skip stale redeliveries
+const DefaultOrderWindow = time.Hour
+
+// Headers of dead lettered messages
+const (
+	HeaderSubject    = "Feeds-Subject"
+	HeaderError      = "Feeds-Error"
+	HeaderDeliveries = "Feeds-Deliveries"
+)
+
+// Config settings of the consumer
+type Config struct {
+
+	// Stream JetStream stream capturing FEEDS_SERVICE_QUEUE_PREFIX.odds_change and FEEDS_SERVICE_QUEUE_PREFIX.bet_stop
+	Stream string
+
+	// Durable durable consumer name, instances with the same name share the messages
+	Durable string
+
+	// MaxDeliver deliveries of a message before it is published to FEEDS_SERVICE_QUEUE_PREFIX.dead_letter
+	MaxDeliver int
+
+	// AckWait time to apply a message before the server redelivers it
+	AckWait time.Duration
+
+	// RetryDelay delay of the redelivery of a failed message, multiplied by the number of deliveries
+	RetryDelay time.Duration
+
+	// OrderWindow time the last applied message of a match is remembered to skip stale redeliveries
+	OrderWindow time.Duration
+
+	// Workers workers applying messages, the messages of a match are applied by one worker in order
+	Workers int
+
+	// QueueSize messages queued per worker, fetching waits while the queue of a match is full
+	QueueSize int
+}
+
+// ConfigFromEnv gets the consumer settings from FEEDS_JS_STREAM, FEEDS_JS_DURABLE, FEEDS_JS_MAX_DELIVER,
+// FEEDS_JS_ACK_WAIT_MS, FEEDS_JS_RETRY_DELAY_MS, FEEDS_JS_WORKERS and FEEDS_JS_QUEUE_SIZE
+func ConfigFromEnv() Config {
+
+	maxDeliver, _ := strconv.Atoi(os.Getenv("FEEDS_JS_MAX_DELIVER"))
+	ackWait, _ := strconv.ParseInt(os.Getenv("FEEDS_JS_ACK_WAIT_MS"), 10, 64)
+	retryDelay, _ := strconv.ParseInt(os.Getenv("FEEDS_JS_RETRY_DELAY_MS"), 10, 64)
+	workers, _ := strconv.Atoi(os.Getenv("FEEDS_JS_WORKERS"))
+	queueSize, _ := strconv.Atoi(os.Getenv("FEEDS_JS_QUEUE_SIZE"))
+
+	return Config{
+		Stream:     os.Getenv("FEEDS_JS_STREAM"),
+		Durable:    os.Getenv("FEEDS_JS_DURABLE"),
+		MaxDeliver: maxDeliver,
+		AckWait:    time.Duration(ackWait) * time.Millisecond,
+		RetryDelay: time.Duration(retryDelay) * time.Millisecond,
+		Workers:    workers,
+		QueueSize:  queueSize,
+	}
+}
+
+// Stats counters of the consumer
+type Stats struct {
+	Received     int64 `json:"received"`
+	Applied      int64 `json:"applied"`
+	Stale        int64 `json:"stale"`
+	Retried      int64 `json:"retried"`
+	DeadLettered int64 `json:"dead_lettered"`
+
+	// Held messages redelivered to wait for a failed message of their match
+	Held int64 `json:"held"`
+
+	// Writes writes to the feed
+	Writes int64 `json:"writes"`
+
+	// Coalesced messages merged into the write of an earlier message of the match
+	Coalesced int64 `json:"coalesced"`
+
+	// Dispatch queue depth and latency of the workers
+	Dispatch dispatch.Stats `json:"dispatch"`
+}
+
+// Consumer applies the OddsChange and BetStop messages of a JetStream stream to a feed. Messages of a match are
+// applied in the order of the stream by one of Workers workers, messages of different matches are applied
+// concurrently. Messages are acknowledged only after the feed saved them, a message that fails is redelivered
+// with a growing delay and after MaxDeliver deliveries or when it can not be decoded is published to
+// FEEDS_SERVICE_QUEUE_PREFIX.dead_letter with the error in its headers. Messages of a match after a failed message
+// are held, redelivered without being applied, until the failed message is applied or dead lettered, so a failed
+// message is never skipped. A redelivered message older than a message of the same match and producer applied in
+// the meantime is acknowledged without being applied.
+//
+// Messages of a match received while the match waits for its worker are coalesced, consecutive OddsChange
+// messages are merged into one write with the latest version of each market, BetStop messages are written in
+// between, so a consumer that falls behind writes each match once per batch instead of once per message
+type Consumer struct {
+	feed     feeds.Feed
+	cfg      Config
+	js       jetstream.JetStream
+	consumer jetstream.Consumer
+	consume  jetstream.ConsumeContext
+	order    *order
+
+	dispatcher *dispatch.Dispatcher
+
+	// pending messages of the matches waiting for their worker
+	mu      sync.Mutex
+	pending map[int64][]delivery
+
+	received     int64
+	applied      int64
+	stale        int64
+	retried      int64
+	deadLettered int64
+	held         int64
+	writes       int64
+	coalesced    int64
+}
+
+// New creates or updates the durable consumer of the feeds messages, messages are applied to feed
+func New(feed feeds.Feed, nc *nats.Conn, cfg Config) (*Consumer, error) {
+
+	if len(cfg.Stream) == 0 {
+
+		cfg.Stream = DefaultStream
+	}
+
+	if len(cfg.Durable) == 0 {
+
+		cfg.Durable = DefaultDurable
+	}
+
+	if cfg.MaxDeliver <= 0 {
+
+		cfg.MaxDeliver = DefaultMaxDeliver
+	}
+
+	if cfg.AckWait <= 0 {
+
+		cfg.AckWait = DefaultAckWait
+	}
+
+	if cfg.RetryDelay <= 0 {
+
+		cfg.RetryDelay = DefaultRetryDelay
+	}
+
+	if cfg.OrderWindow <= 0 {
+
+		cfg.OrderWindow = DefaultOrderWindow
+	}
+
+	if cfg.Workers <= 0 {
+
+		cfg.Workers = DefaultWorkers
+	}
+
+	if cfg.QueueSize <= 0 {
+
+		cfg.QueueSize = dispatch.DefaultQueueSize
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+
+		log.Printf("error creating jetstream context %s", err.Error())
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// redeliveries are not capped by the server, a message is terminated only after it is dead lettered
+	consumer, err := js.CreateOrUpdateConsumer(ctx, cfg.Stream, jetstream.ConsumerConfig{
+		Durable:        cfg.Durable,
+		FilterSubjects: []string{utils.NatsSubject(constants.OddsChangeTopic), utils.NatsSubject(constants.BetStopTopic)},
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        cfg.AckWait,
+		MaxDeliver:     -1,
+	})
+	if err != nil {
+
+		log.Printf("error creating consumer %s of stream %s | %s", cfg.Durable, cfg.Stream, err.Error())
+		return nil, err
+	}
+
+	return &Consumer{
+		feed:     feed,
+		cfg:      cfg,
+		js:       js,
+		consumer: consumer,
+		order:    newOrder(cfg.OrderWindow),
+
+		dispatcher: dispatch.New(cfg.Workers, cfg.QueueSize),
+		pending:    make(map[int64][]delivery),
+	}, nil
+}
+
+// Start starts applying messages
+func (c *Consumer) Start() error {
+
+	consume, err := c.consumer.Consume(c.handle)
+	if err != nil {
+
+		log.Printf("error consuming %s of stream %s | %s", c.cfg.Durable, c.cfg.Stream, err.Error())
+		return err
+	}
+
+	c.consume = consume
+	return nil
+}
+
+// Stop stops fetching messages and returns after the fetched messages are applied, messages not applied
+// within AckWait are left to be redelivered
+func (c *Consumer) Stop() {
+
+	if c.consume != nil {
+
+		c.consume.Drain()
+		<-c.consume.Closed()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.AckWait)
+	defer cancel()
+
+	err := c.dispatcher.Drain(ctx)
+	if err != nil {
+
+		log.Printf("error draining consumer %s | %s", c.cfg.Durable, err.Error())
+	}
+}
+
+// Stats gets the counters of the consumer
+func (c *Consumer) Stats() Stats {
+
+	return Stats{
+		Received:     atomic.LoadInt64(&c.received),
+		Applied:      atomic.LoadInt64(&c.applied),
+		Stale:        atomic.LoadInt64(&c.stale),
+		Retried:      atomic.LoadInt64(&c.retried),
+		DeadLettered: atomic.LoadInt64(&c.deadLettered),
+		Held:         atomic.LoadInt64(&c.held),
+		Writes:       atomic.LoadInt64(&c.writes),
+		Coalesced:    atomic.LoadInt64(&c.coalesced),
+		Dispatch:     c.dispatcher.Stats(),
+	}
+}
+
+func (c *Consumer) handle(msg jetstream.Msg) {
+
+	atomic.AddInt64(&c.received, 1)
+
+	message, err := Decode(msg.Subject(), msg.Data())
+	if err != nil {
+
+		log.Printf("error decoding message of %s | %s", msg.Subject(), err.Error())
+		c.deadLetter(msg, err)
+		return
+	}
+
+	c.enqueue(delivery{msg: msg, message: message})
+}
+
+// enqueue adds the message to the pending messages of its match, the match is dispatched to its worker when
+// it has no pending messages yet
+func (c *Consumer) enqueue(d delivery) {
+
+	matchID := d.message.MatchID()
+
+	c.mu.Lock()
+	deliveries, queued := c.pending[matchID]
+	c.pending[matchID] = append(deliveries, d)
+	c.mu.Unlock()
+
+	if queued {
+
+		return
+	}
+
+	err := c.dispatcher.Dispatch(matchID, func() {
+
+		c.process(matchID)
+	})
+	if err != nil {
+
+		// stopped, the messages are redelivered
+		for _, d := range c.take(matchID) {
+
+			err = d.msg.Nak()
+			if err != nil {
+
+				log.Printf("error rejecting message of %s | %s", d.msg.Subject(), err.Error())
+			}
+		}
+	}
+}
+
+// take gets and removes the pending messages of the match
+func (c *Consumer) take(matchID int64) []delivery {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deliveries := c.pending[matchID]
+	delete(c.pending, matchID)
+
+	return deliveries
+}
+
+// process applies the pending messages of the match coalesced and acknowledges them. Processing of the match stops
+// at a failed write, the messages of the failed write are redelivered or dead lettered and the later messages are
+// held, redelivered after the failed messages, so they are not applied before the failed messages
+func (c *Consumer) process(matchID int64) {
+
+	var deliveries []delivery
+	var held []delivery
+
+	for _, d := range c.take(matchID) {
+
+		src := source{matchID: matchID, producerID: d.message.ProducerID()}
+
+		if c.order.held(src, d.message.Timestamp()) {
+
+			held = append(held, d)
+			continue
+		}
+
+		// only a redelivery can be older than an applied message, a first delivery is in stream order even when
+		// the timestamps of the producer are not
+		if redelivered(d.msg) && c.order.stale(src, d.message.Timestamp()) {
+
+			atomic.AddInt64(&c.stale, 1)
+			c.ack(d.msg)
+			continue
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	c.hold(held, c.cfg.RetryDelay)
+
+	writes := coalesce(deliveries)
+
+	for i, w := range writes {
+
+		atomic.AddInt64(&c.writes, 1)
+
+		err := w.message.Apply(c.feed)
+		if err != nil {
+
+			delay := c.failed(w, err)
+
+			// later writes are held until after the redelivery of the failed messages, applying them would make
+			// the failed messages stale
+			for _, later := range writes[i+1:] {
+
+				c.hold(later.deliveries, delay+c.cfg.RetryDelay)
+			}
+
+			return
+		}
+
+		src := w.source()
+
+		c.order.done(src, w.timestamp())
+		c.order.resolve(src, w.timestamps())
+		atomic.AddInt64(&c.applied, int64(len(w.deliveries)))
+		atomic.AddInt64(&c.coalesced, int64(len(w.deliveries)-1))
+
+		for _, d := range w.deliveries {
+
+			c.ack(d.msg)
+		}
+	}
+}
+
+// failed redelivers or dead letters the messages of a failed write and returns the longest redelivery delay,
+// redelivered messages hold the later messages of the producer of the match until they are applied
+func (c *Consumer) failed(w *write, cause error) time.Duration {
+
+	var retried []int64
+	var deadLettered []int64
+
+	longest := time.Duration(0)
+
+	for _, d := range w.deliveries {
+
+		delay, ok := c.retry(d.msg, cause)
+		if !ok {
+
+			deadLettered = append(deadLettered, d.message.Timestamp())
+			continue
+		}
+
+		retried = append(retried, d.message.Timestamp())
+
+		if delay > longest {
+
+			longest = delay
+		}
+	}
+
+	c.order.resolve(w.source(), deadLettered)
+
+	// the redelivery may go to another instance, the later messages are not held forever
+	c.order.fail(w.source(), retried, time.Now().Add(longest+c.cfg.AckWait))
+
+	return longest
+}
+
+// hold redelivers messages waiting for failed messages of their match after delay
+func (c *Consumer) hold(deliveries []delivery, delay time.Duration) {
+
+	for _, d := range deliveries {
+
+		atomic.AddInt64(&c.held, 1)
+
+		err := d.msg.NakWithDelay(delay)
+		if err != nil {
+
+			log.Printf("error rejecting message of %s | %s", d.msg.Subject(), err.Error())
+		}
+	}
+}
+
+// redelivered checks if the message was delivered before
+func redelivered(msg jetstream.Msg) bool {
+
+	meta, err := msg.Metadata()
+	return err == nil && meta.NumDelivered > 1
+}
+
+func (c *Consumer) ack(msg jetstream.Msg) {
+
+	err := msg.Ack()
+	if err != nil {
+
+		log.Printf("error acknowledging message of %s | %s", msg.Subject(), err.Error())
+	}
+}
+
+// retry redelivers the failed message with a delay growing with its deliveries and returns the delay, or dead letters
+// it after MaxDeliver. ok is false when the message is dead lettered
+func (c *Consumer) retry(msg jetstream.Msg, cause error) (delay time.Duration, ok bool) {
+
+	deliveries := uint64(1)
+
+	meta, err := msg.Metadata()
+	if err == nil {
+
+		deliveries = meta.NumDelivered
+	}
+
+	if deliveries >= uint64(c.cfg.MaxDeliver) {
+
+		log.Printf("error applying message of %s after %d deliveries | %s", msg.Subject(), deliveries, cause.Error())
+		c.deadLetter(msg, cause)
+		return 0, false
+	}
+
+	atomic.AddInt64(&c.retried, 1)
+
+	delay = time.Duration(deliveries) * c.cfg.RetryDelay
+
+	err = msg.NakWithDelay(delay)
+	if err != nil {
+
+		log.Printf("error rejecting message of %s | %s", msg.Subject(), err.Error())
+	}
+
+	return delay, true
+}
+
+// deadLetter publishes the message to the dead letter subject and terminates it, a message that can not be
+// dead lettered is redelivered
+func (c *Consumer) deadLetter(msg jetstream.Msg, cause error) {
+
+	deliveries := uint64(1)
+
+	meta, err := msg.Metadata()
+	if err == nil {
+
+		deliveries = meta.NumDelivered
+	}
+
+	dead := nats.NewMsg(utils.NatsSubject(constants.DeadLetterTopic))
+	dead.Data = msg.Data()
+	dead.Header.Set(HeaderSubject, msg.Subject())
+	dead.Header.Set(HeaderError, cause.Error())
+	dead.Header.Set(HeaderDeliveries, fmt.Sprintf("%d", deliveries))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = c.js.PublishMsg(ctx, dead)
+	if err != nil {
+
+		log.Printf("error dead lettering message of %s | %s", msg.Subject(), err.Error())
+
+		err = msg.NakWithDelay(c.cfg.RetryDelay)
+		if err != nil {
+
+			log.Printf("error rejecting message of %s | %s", msg.Subject(), err.Error())
+		}
+
+		return
+	}
+
+	atomic.AddInt64(&c.deadLettered, 1)
+
+	err = msg.Term()
+	if err != nil {
+
+		log.Printf("error terminating message of %s | %s", msg.Subject(), err.Error())
+	}
+}
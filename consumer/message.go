@@ -0,0 +1,98 @@
+package consumer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// Message a decoded feeds message, one of OddsChange or BetStop is set
+type Message struct {
+	OddsChange *models.OddsChange
+	BetStop    *models.BetStop
+}
+
+// Decode decodes the payload of a message of the subject, the topic is the last token of the subject
+// e.g FEEDS_SERVICE_QUEUE_PREFIX.odds_change
+func Decode(subject string, data []byte) (Message, error) {
+
+	topic := subject[strings.LastIndex(subject, ".")+1:]
+
+	switch topic {
+
+	case constants.OddsChangeTopic:
+		var odds models.OddsChange
+
+		err := json.Unmarshal(data, &odds)
+		if err != nil {
+
+			return Message{}, err
+		}
+
+		return Message{OddsChange: &odds}, nil
+
+	case constants.BetStopTopic:
+		var betStop models.BetStop
+
+		err := json.Unmarshal(data, &betStop)
+		if err != nil {
+
+			return Message{}, err
+		}
+
+		return Message{BetStop: &betStop}, nil
+
+	}
+
+	return Message{}, fmt.Errorf("unknown topic %s", topic)
+}
+
+// MatchID gets the match of the message
+func (m Message) MatchID() int64 {
+
+	if m.OddsChange != nil {
+
+		return m.OddsChange.MatchID
+	}
+
+	return m.BetStop.MatchID
+}
+
+// ProducerID gets the producer of the message
+func (m Message) ProducerID() int64 {
+
+	if m.OddsChange != nil {
+
+		return m.OddsChange.ProducerID
+	}
+
+	return m.BetStop.ProducerID
+}
+
+// Timestamp gets the betradar timestamp of the message, the order of the messages of a producer of a match
+func (m Message) Timestamp() int64 {
+
+	if m.OddsChange != nil {
+
+		return m.OddsChange.BetradarTimestamp
+	}
+
+	return m.BetStop.BetradarTimestamp
+}
+
+// Apply writes the message to the feed
+func (m Message) Apply(feed feeds.Feed) error {
+
+	if m.OddsChange != nil {
+
+		_, err := feed.OddsChange(*m.OddsChange)
+		return err
+	}
+
+	b := m.BetStop
+	return feed.BetStop(b.ProducerID, b.MatchID, b.Status, b.StatusName, b.BetradarTimestamp, b.PublishTimestamp, b.ProcessingTime, b.NetworkLatency)
+}
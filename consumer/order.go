@@ -0,0 +1,178 @@
+package consumer
+
+import (
+	"sync"
+	"time"
+)
+
+// order keeps the timestamp of the last message applied to each producer of a match, so a redelivered message older
+// than a message of the producer already applied is not written over it. It also keeps the timestamps of the failed
+// messages of each producer of a match waiting for their redelivery, later messages of the producer are held until
+// the failed messages are applied so a failed message never becomes stale. Timestamps of different producers are
+// not ordered against each other, the producers of a match are ordered independently
+type order struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	applied map[source]appliedMessage
+	failed  map[source]map[int64]time.Time
+	writes  int
+}
+
+// source a producer of a match, the messages of a source are ordered by their timestamp
+type source struct {
+	matchID    int64
+	producerID int64
+}
+
+type appliedMessage struct {
+	timestamp int64
+	at        time.Time
+}
+
+func newOrder(window time.Duration) *order {
+
+	return &order{
+		window:  window,
+		applied: make(map[source]appliedMessage),
+		failed:  make(map[source]map[int64]time.Time),
+	}
+}
+
+// fail records failed messages of the source, they hold the later messages of the source until they are resolved
+// or until expiry when their redelivery is not received e.g when it is delivered to another instance
+func (o *order) fail(src source, timestamps []int64, expiry time.Time) {
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	failed, ok := o.failed[src]
+	if !ok {
+
+		failed = make(map[int64]time.Time)
+		o.failed[src] = failed
+	}
+
+	for _, timestamp := range timestamps {
+
+		if timestamp > 0 {
+
+			failed[timestamp] = expiry
+		}
+	}
+
+	if len(failed) == 0 {
+
+		delete(o.failed, src)
+	}
+}
+
+// resolve removes failed messages of the source once they are applied or dead lettered
+func (o *order) resolve(src source, timestamps []int64) {
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	failed, ok := o.failed[src]
+	if !ok {
+
+		return
+	}
+
+	for _, timestamp := range timestamps {
+
+		delete(failed, timestamp)
+	}
+
+	if len(failed) == 0 {
+
+		delete(o.failed, src)
+	}
+}
+
+// held checks if the message of the source has to wait for failed messages of the source, messages newer than
+// the failed messages or without timestamp wait, the failed messages themselves and older messages do not
+func (o *order) held(src source, timestamp int64) bool {
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	failed, ok := o.failed[src]
+	if !ok {
+
+		return false
+	}
+
+	now := time.Now()
+	latest := int64(0)
+
+	for ts, expiry := range failed {
+
+		if now.After(expiry) {
+
+			delete(failed, ts)
+			continue
+		}
+
+		if ts > latest {
+
+			latest = ts
+		}
+	}
+
+	if len(failed) == 0 {
+
+		delete(o.failed, src)
+		return false
+	}
+
+	return timestamp == 0 || timestamp > latest
+}
+
+// stale checks if a message of the source newer than timestamp was already applied
+func (o *order) stale(src source, timestamp int64) bool {
+
+	if timestamp == 0 {
+
+		return false
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	last, ok := o.applied[src]
+	return ok && timestamp < last.timestamp
+}
+
+// done records the timestamp of a message of the source applied to the feed
+func (o *order) done(src source, timestamp int64) {
+
+	if timestamp == 0 {
+
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+
+	if last, ok := o.applied[src]; !ok || timestamp >= last.timestamp {
+
+		o.applied[src] = appliedMessage{timestamp: timestamp, at: now}
+	}
+
+	o.writes++
+
+	// sources without messages within the window can no longer get redeliveries
+	if o.writes%1024 == 0 {
+
+		for s, last := range o.applied {
+
+			if now.Sub(last.at) > o.window {
+
+				delete(o.applied, s)
+			}
+		}
+	}
+}
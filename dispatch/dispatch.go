@@ -0,0 +1,208 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultQueueSize jobs queued per worker when the queue size is not set
+const DefaultQueueSize = 256
+
+// ErrClosed the dispatcher is drained and takes no more jobs
+var ErrClosed = errors.New("dispatcher is closed")
+
+// Job a unit of work of a match
+type Job func()
+
+// WorkerStats counters of a worker
+type WorkerStats struct {
+	Worker         int           `json:"worker"`
+	Queued         int           `json:"queued"`
+	Processed      int64         `json:"processed"`
+	AverageLatency time.Duration `json:"average_latency"`
+	MaxLatency     time.Duration `json:"max_latency"`
+}
+
+// Stats counters of the dispatcher
+type Stats struct {
+	Queued    int           `json:"queued"`
+	Processed int64         `json:"processed"`
+	Workers   []WorkerStats `json:"workers"`
+}
+
+// Dispatcher runs the jobs of a match one at a time in the order they were dispatched, jobs of different matches
+// run concurrently. Matches are sharded by matchID across the workers, each worker has its own queue and
+// Dispatch blocks while the queue of the match is full
+type Dispatcher struct {
+	workers []*worker
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+
+	// closing is closed by Drain to release Dispatch calls blocked on a full queue
+	closing chan struct{}
+	once    sync.Once
+}
+
+type worker struct {
+	queue chan Job
+
+	processed  int64
+	latency    int64
+	maxLatency int64
+}
+
+// New creates a dispatcher with the number of workers, each with a queue of queueSize jobs
+func New(workers, queueSize int) *Dispatcher {
+
+	if workers <= 0 {
+
+		workers = 1
+	}
+
+	if queueSize <= 0 {
+
+		queueSize = DefaultQueueSize
+	}
+
+	d := &Dispatcher{closing: make(chan struct{})}
+
+	for i := 0; i < workers; i++ {
+
+		w := &worker{queue: make(chan Job, queueSize)}
+		d.workers = append(d.workers, w)
+
+		d.wg.Add(1)
+		go d.run(w)
+	}
+
+	return d
+}
+
+// Dispatch queues the job of the match on the worker of the match, it blocks while the queue is full.
+// Returns ErrClosed when the dispatcher is drained, also while it is blocked
+func (d *Dispatcher) Dispatch(matchID int64, job Job) error {
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+
+		return ErrClosed
+	}
+
+	select {
+
+	case d.workers[d.shard(matchID)].queue <- job:
+		return nil
+
+	case <-d.closing:
+		return ErrClosed
+
+	}
+}
+
+// Drain stops taking jobs and returns after the queued jobs ran or when ctx is done
+func (d *Dispatcher) Drain(ctx context.Context) error {
+
+	// release blocked Dispatch calls first, they hold the read lock
+	d.once.Do(func() {
+
+		close(d.closing)
+	})
+
+	d.mu.Lock()
+
+	if !d.closed {
+
+		d.closed = true
+
+		for _, w := range d.workers {
+
+			close(w.queue)
+		}
+	}
+
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+
+	case <-done:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	}
+}
+
+// Stats gets the queue depth and latency of the workers
+func (d *Dispatcher) Stats() Stats {
+
+	var stats Stats
+
+	for i, w := range d.workers {
+
+		processed := atomic.LoadInt64(&w.processed)
+
+		ws := WorkerStats{
+			Worker:     i,
+			Queued:     len(w.queue),
+			Processed:  processed,
+			MaxLatency: time.Duration(atomic.LoadInt64(&w.maxLatency)),
+		}
+
+		if processed > 0 {
+
+			ws.AverageLatency = time.Duration(atomic.LoadInt64(&w.latency) / processed)
+		}
+
+		stats.Queued += ws.Queued
+		stats.Processed += processed
+		stats.Workers = append(stats.Workers, ws)
+	}
+
+	return stats
+}
+
+// shard gets the worker of the match
+func (d *Dispatcher) shard(matchID int64) int {
+
+	return int(uint64(matchID) % uint64(len(d.workers)))
+}
+
+func (d *Dispatcher) run(w *worker) {
+
+	defer d.wg.Done()
+
+	for job := range w.queue {
+
+		start := time.Now()
+		job()
+		latency := int64(time.Since(start))
+
+		atomic.AddInt64(&w.latency, latency)
+		atomic.AddInt64(&w.processed, 1)
+
+		for {
+
+			max := atomic.LoadInt64(&w.maxLatency)
+			if latency <= max || atomic.CompareAndSwapInt64(&w.maxLatency, max, latency) {
+
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatchOrder(t *testing.T) {
+
+	d := New(4, 8)
+
+	var mu sync.Mutex
+	ran := make(map[int64][]int)
+
+	for i := 0; i < 100; i++ {
+
+		for matchID := int64(1); matchID <= 10; matchID++ {
+
+			matchID, i := matchID, i
+
+			err := d.Dispatch(matchID, func() {
+
+				mu.Lock()
+				ran[matchID] = append(ran[matchID], i)
+				mu.Unlock()
+			})
+			if err != nil {
+
+				t.Fatalf("Dispatch(%d): %s", matchID, err.Error())
+			}
+		}
+	}
+
+	err := d.Drain(context.Background())
+	if err != nil {
+
+		t.Fatalf("Drain: %s", err.Error())
+	}
+
+	// the jobs of a match run in the order they were dispatched
+	for matchID, jobs := range ran {
+
+		if len(jobs) != 100 {
+
+			t.Fatalf("match %d ran %d jobs, want 100", matchID, len(jobs))
+		}
+
+		for i, job := range jobs {
+
+			if job != i {
+
+				t.Fatalf("match %d ran job %d at %d", matchID, job, i)
+			}
+		}
+	}
+
+	if stats := d.Stats(); stats.Processed != 1000 || stats.Queued != 0 {
+
+		t.Errorf("stats processed %d queued %d, want 1000 and 0", stats.Processed, stats.Queued)
+	}
+
+	if err := d.Dispatch(1, func() {}); !errors.Is(err, ErrClosed) {
+
+		t.Errorf("Dispatch after Drain = %v, want ErrClosed", err)
+	}
+}
+
+func TestDrainReleasesBlockedDispatch(t *testing.T) {
+
+	d := New(1, 1)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	// the worker runs the first job until released, the second job fills the queue
+	_ = d.Dispatch(1, func() {
+
+		close(started)
+		<-release
+	})
+	<-started
+	_ = d.Dispatch(1, func() {})
+
+	blocked := make(chan error)
+
+	go func() {
+
+		blocked <- d.Dispatch(1, func() {})
+	}()
+
+	drained := make(chan error)
+
+	go func() {
+
+		drained <- d.Drain(context.Background())
+	}()
+
+	select {
+
+	case err := <-blocked:
+		if !errors.Is(err, ErrClosed) {
+
+			t.Errorf("blocked Dispatch = %v, want ErrClosed", err)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Drain did not release the blocked Dispatch")
+
+	}
+
+	close(release)
+
+	if err := <-drained; err != nil {
+
+		t.Errorf("Drain: %s", err.Error())
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+
+	d := New(1, 1)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	_ = d.Dispatch(1, func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := d.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+
+		t.Errorf("Drain = %v, want context.DeadlineExceeded", err)
+	}
+}
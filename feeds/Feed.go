@@ -27,14 +27,17 @@ type Feed interface {
 	// DeleteAllMarkets Deletes all odds and caches for the supplied match
 	DeleteAllMarkets(producerID, matchID int64) error
 
-	// DeleteAll Deletes all odds
-	DeleteAll() error
+	// DeleteAll Deletes all odds, confirmation must match FEEDS_DELETE_ALL_TOKEN
+	DeleteAll(confirmation string) error
 
 	// SetProducerID Sets ProducerID for the specified match
 	SetProducerID(matchID, producerID int64) error
 
-	// GetProducerID Get ProducerID for the specified match
-	GetProducerID(matchID int64) int64
+	// GetProducerID Get ProducerID and the producer status for the specified match
+	GetProducerID(matchID int64) (id, status int64)
+
+	// GetSportID Get SportID for the specified match
+	GetSportID(matchID int64) int64
 
 	// DeleteMatchOdds Delete all odds and caches for the supplied match
 	DeleteMatchOdds(matchID int64)
@@ -43,8 +46,47 @@ type Feed interface {
 	GetDefaultMarketID(matchID, sportID int64) int64
 
 	// GetFixtureStatus gets fixture status for the supplied matchID
-	GetFixtureStatus(matchID int64) *models.FixtureStatus
+	GetFixtureStatus(matchID int64) models.FixtureStatus
 
 	// SetFixtureStatus sets fixture status for the supplied matchID
 	SetFixtureStatus(matchID int64, fx models.FixtureStatus) error
+
+	// GetStoredMarkets Gets all markets as stored for a specified matchID, without overrides, formatting or odds recovery requests
+	GetStoredMarkets(producerID, matchID int64) []models.Market
+
+	// GetMatchIDs Gets the matchIDs with stored markets for the supplied producer
+	GetMatchIDs(producerID int64) []int64
+
+	// SetStoredMarkets Replaces the stored markets of a specified matchID as they are, without merging or skipping locked markets
+	SetStoredMarkets(producerID, matchID, sportID int64, markets []models.Market) error
+
+	// GetStoredFixtureStatus Gets the stored fixture status of a specified matchID, ok is false when none is stored
+	GetStoredFixtureStatus(matchID int64) (fx models.FixtureStatus, ok bool)
+
+	// GetMatchVersion Gets the sequence of the match, incremented every time the markets or fixture status of the match change
+	GetMatchVersion(matchID int64) int64
+
+	// GetAllMarketsWithSequence Gets all markets for a specified matchID and the sequence of the match
+	GetAllMarketsWithSequence(producerID, matchID int64) ([]models.Market, int64)
+
+	// GetMarketsIfChanged Gets all markets for a specified matchID if the sequence of the match is not sinceSequence
+	GetMarketsIfChanged(producerID, matchID, sinceSequence int64) (markets []models.Market, sequence int64, changed bool)
+
+	// GetChangesSince Gets the markets of a specified matchID changed after sinceSequence, all markets when a full resync is required
+	GetChangesSince(producerID, matchID, sinceSequence int64) models.MatchChanges
+
+	// GetAllMarketsByLocale Gets all markets for a specified matchID with names translated to the supplied locale
+	GetAllMarketsByLocale(producerID, matchID int64, locale string) []models.Market
+
+	// GetMarketByLocale Gets only markets for the supplied matchID and specifier with names translated to the supplied locale
+	GetMarketByLocale(producerID, matchID, marketID int64, specifier, locale string) *models.Market
+
+	// GetOddsByLocale Gets Odds for the specified outcome with names translated to the supplied locale
+	GetOddsByLocale(matchID, marketID int64, specifier, outcomeID, locale string) *models.OddsDetails
+
+	// GetAllMarketsOrderByListByLocale Gets all markets for a specified matchID order by the supplied ordered list with names translated to the supplied locale
+	GetAllMarketsOrderByListByLocale(producerID, matchID int64, marketOderList []models.MarketOrderList, locale string) []models.Market
+
+	// GetSpecifiedMarketsByLocale Gets all markets for a specified matchID only retrieve markets in the supplied list with names translated to the supplied locale
+	GetSpecifiedMarketsByLocale(producerID, matchID int64, marketList []models.MarketOrderList, locale string) []models.Market
 }
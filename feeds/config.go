@@ -0,0 +1,33 @@
+package feeds
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Configured implemented by feeds whose reads depend on configuration besides the stored markets e.g translations,
+// pricing rules, market filters or the odds format. ConfigVersion changes whenever that configuration changes
+type Configured interface {
+	ConfigVersion() string
+}
+
+// ConfigVersion gets the configuration version of the feed, empty when the feed is not Configured
+func ConfigVersion(feed Feed) string {
+
+	if c, ok := feed.(Configured); ok {
+
+		return c.ConfigVersion()
+	}
+
+	return ""
+}
+
+// ConfigHash gets a hash of the configuration of a wrapping feed and the configuration version of the wrapped feed,
+// used by feed wrappers to implement Configured
+func ConfigHash(feed Feed, config interface{}) string {
+
+	js, _ := json.Marshal(config)
+	sum := sha1.Sum(append(js, ConfigVersion(feed)...))
+	return hex.EncodeToString(sum[:8])
+}
@@ -11,21 +11,20 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
-// DbInstance gets MySQL DB Instance
-func DbInstance() *sql.DB {
-	username := os.Getenv("FEEDS_DATABASE_USERNAME")
-	password := os.Getenv("FEEDS_DATABASE_PASSWORD")
-	dbname := os.Getenv("FEEDS_DATABASE_NAME")
-	host := os.Getenv("FEEDS_DATABASE_HOST")
-	port := os.Getenv("FEEDS_DATABASE_PORT")
+// DatabaseConfig connection settings of a MySQL database
+type DatabaseConfig struct {
+	Username           string
+	Password           string
+	Name               string
+	Host               string
+	Port               string
+	IdleConnections    int
+	MaxConnections     int
+	ConnectionLifetime int
+}
 
-	dbURI := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&multiStatements=true",
-		username, password, host, port, dbname)
-
-	Db, err := sql.Open("mysql", dbURI)
-	if err != nil {
-		log.Fatalf("Error opening database connection: %v", err)
-	}
+// DatabaseConfigFromEnv gets MySQL connection settings from the FEEDS_DATABASE_* environment variables
+func DatabaseConfigFromEnv() DatabaseConfig {
 
 	idleConnection := os.Getenv("FEEDS_DATABASE_IDLE_CONNECTION")
 	ic, err := strconv.Atoi(idleConnection)
@@ -45,10 +44,39 @@ func DbInstance() *sql.DB {
 		cl = 60
 	}
 
-	Db.SetMaxIdleConns(ic)
-	Db.SetConnMaxLifetime(time.Second * time.Duration(cl))
-	Db.SetMaxOpenConns(mx)
-	Db.SetConnMaxIdleTime(time.Second * time.Duration(cl))
+	return DatabaseConfig{
+		Username:           os.Getenv("FEEDS_DATABASE_USERNAME"),
+		Password:           os.Getenv("FEEDS_DATABASE_PASSWORD"),
+		Name:               os.Getenv("FEEDS_DATABASE_NAME"),
+		Host:               os.Getenv("FEEDS_DATABASE_HOST"),
+		Port:               os.Getenv("FEEDS_DATABASE_PORT"),
+		IdleConnections:    ic,
+		MaxConnections:     mx,
+		ConnectionLifetime: cl,
+	}
+}
+
+// DbInstance gets MySQL DB Instance
+func DbInstance() *sql.DB {
+
+	return NewDbInstance(DatabaseConfigFromEnv())
+}
+
+// NewDbInstance gets MySQL DB Instance for the supplied settings
+func NewDbInstance(cfg DatabaseConfig) *sql.DB {
+
+	dbURI := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&multiStatements=true",
+		cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+
+	Db, err := sql.Open("mysql", dbURI)
+	if err != nil {
+		log.Fatalf("Error opening database connection: %v", err)
+	}
+
+	Db.SetMaxIdleConns(cfg.IdleConnections)
+	Db.SetConnMaxLifetime(time.Second * time.Duration(cfg.ConnectionLifetime))
+	Db.SetMaxOpenConns(cfg.MaxConnections)
+	Db.SetConnMaxIdleTime(time.Second * time.Duration(cfg.ConnectionLifetime))
 
 	err = Db.Ping()
 	if err != nil {
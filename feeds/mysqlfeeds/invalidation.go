@@ -0,0 +1,21 @@
+package mysqlfeeds
+
+import (
+	"time"
+
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// publishInvalidation publishes to odds_invalidation that the stored markets of the match changed,
+// read caches of all instances drop the match. producerID 0 invalidates all producers, sequence is the sequence of the match after the change
+func (rds *MysqlFeed) publishInvalidation(producerID, matchID, sequence int64) {
+
+	utils.PublishToNats(rds.NatsClient, constants.OddsInvalidationTopic, models.OddsInvalidation{
+		MatchID:    matchID,
+		ProducerID: producerID,
+		Sequence:   sequence,
+		Timestamp:  time.Now().UnixMilli(),
+	})
+}
@@ -0,0 +1,40 @@
+package mysqlfeeds
+
+import (
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// GetAllMarketsByLocale gets all markets with odds for a particular matchID with market and outcome names translated to the supplied locale
+func (rds *MysqlFeed) GetAllMarketsByLocale(producerID, matchID int64, locale string) []models.Market {
+
+	return rds.Translations.TranslateMarkets(rds.GetAllMarkets(producerID, matchID), locale)
+
+}
+
+// GetMarketByLocale gets market with odds for a particular matchID and marketID translated to the supplied locale
+func (rds *MysqlFeed) GetMarketByLocale(producerID, matchID, marketID int64, specifier, locale string) *models.Market {
+
+	return rds.Translations.TranslateMarket(rds.GetMarket(producerID, matchID, marketID, specifier), locale)
+
+}
+
+// GetOddsByLocale gets odds from quadruplets matchID, marketID , specifier and outcomeID translated to the supplied locale
+func (rds *MysqlFeed) GetOddsByLocale(matchID, marketID int64, specifier, outcomeID, locale string) *models.OddsDetails {
+
+	return rds.Translations.TranslateOddsDetails(rds.GetOdds(matchID, marketID, specifier, outcomeID), locale)
+
+}
+
+// GetAllMarketsOrderByListByLocale gets all markets with odds for a particular matchID order by the supplied list of markets translated to the supplied locale
+func (rds *MysqlFeed) GetAllMarketsOrderByListByLocale(producerID, matchID int64, marketOderList []models.MarketOrderList, locale string) []models.Market {
+
+	return rds.Translations.TranslateMarkets(rds.GetAllMarketsOrderByList(producerID, matchID, marketOderList), locale)
+
+}
+
+// GetSpecifiedMarketsByLocale gets the specified markets with odds for a particular matchID translated to the supplied locale
+func (rds *MysqlFeed) GetSpecifiedMarketsByLocale(producerID, matchID int64, marketList []models.MarketOrderList, locale string) []models.Market {
+
+	return rds.Translations.TranslateMarkets(rds.GetSpecifiedMarkets(producerID, matchID, marketList), locale)
+
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/go-redis/redis"
 	goutils "github.com/mudphilo/go-utils"
 	"github.com/nats-io/nats.go"
+	"github.com/touchvas/odds-sdk/v2/analytics"
+	"github.com/touchvas/odds-sdk/v2/audit"
+	"github.com/touchvas/odds-sdk/v2/changelog"
 	"github.com/touchvas/odds-sdk/v2/constants/sport_event_status"
 	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/keyspace"
 	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/oddsformat"
+	"github.com/touchvas/odds-sdk/v2/overrides"
+	"github.com/touchvas/odds-sdk/v2/translations"
 	"github.com/touchvas/odds-sdk/v2/utils"
 	"log"
 	"os"
@@ -22,9 +29,19 @@ import (
 
 type MysqlFeed struct {
 	feeds.Feed
-	DB          *sql.DB
-	NatsClient  *nats.Conn
-	RedisClient *redis.Client
+	DB                  *sql.DB
+	NatsClient          *nats.Conn
+	RedisClient         utils.Redis
+	Translations        *translations.Store
+	OddsFormat          oddsformat.Format
+	OverroundThresholds *analytics.OverroundThresholds
+	Overrides           *overrides.Store
+	Audit               *audit.Logger
+	Keys                keyspace.Keyspace
+	Database            string
+
+	// ctx bounds the redis and MySQL calls of the feed, see WithContext
+	ctx context.Context
 }
 
 type marketTmp struct {
@@ -63,25 +80,123 @@ type marketTmp struct {
 	Probability float64 `json:"probability"  validate:"required"`
 }
 
+// Config settings of a MySQL feed instance
+type Config struct {
+
+	// Database connection settings, only Name is used when DB is set, it identifies the database in audit entries
+	Database DatabaseConfig
+
+	// DB optional database connection shared with other instances
+	DB *sql.DB
+
+	// Namespace feed namespace of the redis keys
+	Namespace string
+
+	// KeyPrefix tenant prefix of all redis keys, required when tenants share a redis database
+	KeyPrefix string
+
+	// Redis connection settings, not used when RedisClient is set
+	Redis utils.RedisConfig
+
+	// RedisClient optional redis client shared with other instances
+	RedisClient utils.Redis
+
+	// NatsClient optional nats connection shared with other instances, connects with FEEDS_SERVICE_NATS_URI when not set
+	NatsClient *nats.Conn
+}
+
 var instance *MysqlFeed
 var once sync.Once
 
+// ConfigFromEnv gets the settings of the default instance from the environment
+func ConfigFromEnv() Config {
+
+	return Config{
+		Database:  DatabaseConfigFromEnv(),
+		Namespace: os.Getenv("ODDS_FEED_NAMESPACE"),
+		Redis:     utils.RedisConfigFromEnv(),
+	}
+}
+
+// GetFeedsInstance gets the default instance configured from the environment
 func GetFeedsInstance() *MysqlFeed {
 
 	once.Do(func() {
 
 		fmt.Println("Creating Redis Feeds instance")
-		instance = &MysqlFeed{
-			DB:          DbInstance(),
-			NatsClient:  utils.GetNatsConnection(),
-			RedisClient: utils.RedisClient(),
-		}
+		instance = New(ConfigFromEnv())
 	})
 
 	return instance
 
 }
 
+// New creates a MySQL feed instance with the supplied settings, use it to run feeds of several databases in one process
+func New(cfg Config) *MysqlFeed {
+
+	redisClient := cfg.RedisClient
+	if redisClient == nil {
+
+		redisClient = utils.NewRedisClient(cfg.Redis)
+	}
+
+	natsClient := cfg.NatsClient
+	if natsClient == nil {
+
+		natsClient = utils.GetNatsConnection()
+	}
+
+	db := cfg.DB
+	if db == nil {
+
+		db = NewDbInstance(cfg.Database)
+	}
+
+	keys := keyspace.New(cfg.Namespace).WithPrefix(cfg.KeyPrefix).WithHashTags(cfg.Redis.HashTags)
+
+	translationsStore := translations.NewStore(redisClient)
+	translationsStore.Keys = keys
+
+	overridesStore := overrides.NewStore(redisClient)
+	overridesStore.Keys = keys
+	overridesStore.NatsClient = natsClient
+
+	return &MysqlFeed{
+		DB:                  db,
+		NatsClient:          natsClient,
+		RedisClient:         redisClient,
+		Translations:        translationsStore,
+		OddsFormat:          oddsformat.FormatFromEnv(),
+		OverroundThresholds: analytics.ThresholdsFromEnv(),
+		Overrides:           overridesStore,
+		Audit:               audit.NewLogger("mysql", cfg.Database.Name, audit.SinksFromEnv(redisClient, db)...),
+		Keys:                keys,
+		Database:            cfg.Database.Name,
+	}
+}
+
+// WithContext gets a copy of the feed whose redis and MySQL calls are bound by the supplied context
+// e.g the deadline of a grpc request, the copy shares the clients of the feed
+func (rds *MysqlFeed) WithContext(ctx context.Context) *MysqlFeed {
+
+	feed := *rds
+	feed.ctx = ctx
+	feed.Translations = rds.Translations.WithContext(ctx)
+	feed.Overrides = rds.Overrides.WithContext(ctx)
+	return &feed
+}
+
+// context gets the context of the redis and MySQL calls, background when the feed has no context
+func (rds *MysqlFeed) context() context.Context {
+
+	if rds.ctx == nil {
+
+		return context.Background()
+	}
+
+	return rds.ctx
+}
+
 // OddsChange Update new odds change message
 func (rds *MysqlFeed) OddsChange(odds models.OddsChange) (int, error) {
 
@@ -121,7 +236,17 @@ func (rds *MysqlFeed) OddsChange(odds models.OddsChange) (int, error) {
 		return 0, nil
 	}
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	// flag markets with overround out of range as a data quality signal
+	rds.checkOverround(odds)
+
+	// markets locked by traders are not updated until the lock is cleared
+	odds.Markets = rds.Overrides.FilterLocked(odds.MatchID, odds.Markets)
+	if len(odds.Markets) == 0 {
+
+		return 0, nil
+	}
+
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	matchDetails := make(map[string]interface{})
 
@@ -286,13 +411,16 @@ func (rds *MysqlFeed) OddsChange(odds models.OddsChange) (int, error) {
 
 	}
 
+	rds.matchChanged(odds.ProducerID, odds.MatchID, changelog.Entry{ProducerID: odds.ProducerID, Markets: changelog.MarketKeys(odds.Markets)})
+
 	return 0, nil
 
 }
 
+// SetProducerID sets the active producer for a particular match
 func (rds *MysqlFeed) SetProducerID(matchID, producerID int64) error {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 	updates := map[string]interface{}{
 		"match_id":    matchID,
 		"producer_id": producerID,
@@ -302,15 +430,22 @@ func (rds *MysqlFeed) SetProducerID(matchID, producerID int64) error {
 	if err != nil {
 
 		log.Printf("error updating match_odds_details %s ", err.Error())
+
+	} else {
+
+		// reads of the active producer of the match change with the producer
+		rds.matchChanged(0, matchID, changelog.Entry{Resync: true})
 	}
 
+	rds.Audit.Record("SetProducerID", map[string]interface{}{"match_id": matchID, "producer_id": producerID}, 1, err)
+
 	return err
 }
 
 // GetProducerID gets the active producer for a particular match
 func (rds *MysqlFeed) GetProducerID(matchID int64) (id, status int64) {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 	query := "SELECT m.producer_id, p.producer_status " +
 		" FROM match_odds_details m " +
 		" INNER JOIN producer p ON m.producer_id = p.producer_id " +
@@ -332,13 +467,41 @@ func (rds *MysqlFeed) GetProducerID(matchID int64) (id, status int64) {
 
 }
 
+// GetSportID gets the sportID for a particular match
+func (rds *MysqlFeed) GetSportID(matchID int64) int64 {
+
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
+
+	for _, table := range []string{"live_odds", "odds"} {
+
+		dbUtils.SetQuery(fmt.Sprintf("SELECT sport_id FROM %s WHERE match_id = ? LIMIT 1 ", table))
+		dbUtils.SetParams(matchID)
+
+		var sportID sql.NullInt64
+
+		err := dbUtils.FetchOneWithContext().Scan(&sportID)
+		if err != nil && err != sql.ErrNoRows {
+
+			log.Printf("error getting sport_id from %s %s ", table, err.Error())
+			continue
+		}
+
+		if sportID.Int64 > 0 {
+
+			return sportID.Int64
+		}
+	}
+
+	return 0
+}
+
 // BetStop process bet stop message, this message suspends all the markets
 // the markets will be openned up again by subsequent odds change message
 func (rds *MysqlFeed) BetStop(producerID, matchID, status int64, statusName string, betradarTimeStamp, publishTimestamp, publisherProcessingTime, networkLatency int64) error {
 
 	arrival := time.Now().UnixMilli()
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	table := "live_odds"
 
@@ -389,13 +552,83 @@ func (rds *MysqlFeed) BetStop(producerID, matchID, status int64, statusName stri
 
 	}
 
+	rds.matchChanged(producerID, matchID, changelog.Entry{ProducerID: producerID, AllMarkets: true})
+
 	return nil
 }
 
 // GetAllMarkets gets all markets with odds for a particular matchID
 func (rds *MysqlFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	markets, err := rds.storedMarkets(producerID, matchID)
+	if err != nil && err == sql.ErrNoRows {
+
+		rds.RequestOdds(matchID)
+		return nil
+	}
+
+	if err != nil {
+
+		return nil
+	}
+
+	return rds.prepareMarkets(matchID, markets)
+}
+
+// GetStoredMarkets gets the markets as stored for a particular matchID, without overrides, formatting or odds recovery requests
+func (rds *MysqlFeed) GetStoredMarkets(producerID, matchID int64) []models.Market {
+
+	markets, _ := rds.storedMarkets(producerID, matchID)
+	return markets
+}
+
+// GetMatchIDs gets the matchIDs with stored markets for the supplied producer
+func (rds *MysqlFeed) GetMatchIDs(producerID int64) []int64 {
+
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
+
+	table := "live_odds"
+
+	if producerID == 3 {
+
+		table = "odds"
+
+	}
+
+	dbUtils.SetQuery(fmt.Sprintf("SELECT DISTINCT match_id FROM %s ORDER BY match_id", table))
+	dbUtils.SetParams()
+
+	rows, err := dbUtils.FetchWithContext()
+	if err != nil {
+
+		log.Printf("error getting matchIDs from %s | %s ", table, err.Error())
+		return nil
+	}
+
+	defer rows.Close()
+
+	var matchIDs []int64
+
+	for rows.Next() {
+
+		var matchID sql.NullInt64
+
+		err = rows.Scan(&matchID)
+		if err != nil {
+
+			log.Printf("error scanning matchID from %s | %s ", table, err.Error())
+			continue
+		}
+
+		matchIDs = append(matchIDs, matchID.Int64)
+	}
+
+	return matchIDs
+}
+
+func (rds *MysqlFeed) storedMarkets(producerID, matchID int64) ([]models.Market, error) {
+
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	table := "live_odds"
 
@@ -412,16 +645,10 @@ func (rds *MysqlFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
 	dbUtils.SetParams(matchID)
 
 	rows, err := dbUtils.FetchWithContext()
-	if err != nil && err == sql.ErrNoRows {
-
-		rds.RequestOdds(matchID)
-		return nil
-	}
-
 	if err != nil {
 
 		log.Printf("error getting odds for matchID %d | %s ", matchID, err.Error())
-		return nil
+		return nil, err
 	}
 
 	defer rows.Close()
@@ -443,12 +670,8 @@ func (rds *MysqlFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
 
 		marketKey := fmt.Sprintf("%d:%s", market_id.Int64, specifier.String)
 
-		var outcomes []marketTmp
-
-		if outC, ok := out[marketKey]; !ok {
-
-			outcomes = outC
-		}
+		// append to the outcomes already read for this market
+		outcomes := out[marketKey]
 
 		outcomes = append(outcomes, marketTmp{
 			MarketID:    market_id.Int64,
@@ -515,13 +738,13 @@ func (rds *MysqlFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
 
 	}
 
-	return markets
+	return markets, nil
 }
 
 // GetMarket gets market with odds for a particular matchID and marketID
 func (rds *MysqlFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	table := "live_odds"
 
@@ -587,7 +810,7 @@ func (rds *MysqlFeed) GetMarket(producerID, matchID, marketID int64, specifier s
 		Outcomes:   outcomes,
 	}
 
-	return &market
+	return rds.prepareMarket(matchID, &market)
 }
 
 // GetOdds gets odds from quadruplets matchID, marketID , specifier and outcomeID
@@ -595,7 +818,7 @@ func (rds *MysqlFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 
 	producerID, _ := rds.GetProducerID(matchID)
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	table := "live_odds"
 
@@ -647,7 +870,7 @@ func (rds *MysqlFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 		ProducerID:  producerID,
 	}
 
-	return &oddT
+	return rds.prepareOdds(&oddT)
 }
 
 func (rds *MysqlFeed) RequestOdds(matchID int64) error {
@@ -765,7 +988,15 @@ func (rds *MysqlFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList
 // DeleteAllMarkets deletes markets for the specified matchID
 func (rds *MysqlFeed) DeleteAllMarkets(producerID, matchID int64) error {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	deleted, err := rds.deleteAllMarkets(producerID, matchID)
+	rds.Audit.Record("DeleteAllMarkets", map[string]interface{}{"producer_id": producerID, "match_id": matchID}, deleted, err)
+	rds.matchChanged(producerID, matchID, changelog.Entry{ProducerID: producerID, Resync: true})
+	return err
+}
+
+func (rds *MysqlFeed) deleteAllMarkets(producerID, matchID int64) (int64, error) {
+
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	table := "live_odds"
 
@@ -779,43 +1010,92 @@ func (rds *MysqlFeed) DeleteAllMarkets(producerID, matchID int64) error {
 		"match_id": matchID,
 	}
 
-	_, err := dbUtils.DeleteWithContext(table, condition)
+	deleted, err := dbUtils.DeleteWithContext(table, condition)
 	if err != nil {
 
 		log.Printf("error deleting data from %s %s ", table, err.Error())
 
 	}
 
-	_, err = dbUtils.DeleteWithContext("match_odds_details", condition)
+	details, err := dbUtils.DeleteWithContext("match_odds_details", condition)
 	if err != nil {
 
 		log.Printf("error deleting data from match_odds_details %s ", err.Error())
 
 	}
 
-	return err
+	return deleted + details, err
 }
 
-// DeleteAll deletes all feeds data
-func (rds *MysqlFeed) DeleteAll() error {
+// DeleteAll deletes all feeds data, confirmation must be the token set in FEEDS_DELETE_ALL_TOKEN (see audit.Confirm).
+// Match sequences and change logs are kept and the sequence of every deleted match is incremented, so sequences and
+// ETags of a match never repeat and readers of the deleted matches are invalidated
+func (rds *MysqlFeed) DeleteAll(confirmation string) error {
+
+	err := audit.Confirm(rds.Database, confirmation)
+	if err != nil {
+
+		rds.Audit.Record("DeleteAll", map[string]interface{}{"confirmed": false}, 0, err)
+		return err
+	}
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 
 	tables := []string{"odds", "live_odds", "match_odds_details"}
 
+	matchIDs := append(rds.GetMatchIDs(1), rds.GetMatchIDs(3)...)
+
+	deleted := int64(0)
+
+	var errs []error
+
 	for _, t := range tables {
 
+		// truncate does not report affected rows, count them before truncating
+		var rows sql.NullInt64
+
+		dbUtils.SetQuery(fmt.Sprintf("SELECT COUNT(*) FROM %s", t))
+		dbUtils.SetParams()
+
+		err = dbUtils.FetchOneWithContext().Scan(&rows)
+		if err != nil {
+
+			log.Printf("error counting table %s %s ", t, err.Error())
+			errs = append(errs, fmt.Errorf("error counting table %s | %s", t, err))
+
+		}
+
+		// truncate commits implicitly, it is not run in a transaction
 		dbUtils.SetQuery(fmt.Sprintf("TRUNCATE TABLE %s", t))
-		_, err := dbUtils.UpdateQueryWithContextTx()
+		_, err = dbUtils.UpdateQueryWithContext()
 		if err != nil {
 
 			log.Printf("error truncating table %s %s ", t, err.Error())
+			errs = append(errs, fmt.Errorf("error truncating table %s | %s", t, err))
+			continue
 
 		}
 
+		deleted += rows.Int64
+
 	}
 
-	return nil
+	changed := make(map[int64]bool)
+
+	for _, matchID := range matchIDs {
+
+		if !changed[matchID] {
+
+			changed[matchID] = true
+			rds.matchChanged(0, matchID, changelog.Entry{Resync: true})
+		}
+	}
+
+	err = errors.Join(errs...)
+
+	rds.Audit.Record("DeleteAll", map[string]interface{}{"confirmed": true, "tables": tables, "matches": len(changed)}, deleted, err)
+
+	return err
 
 }
 
@@ -824,41 +1104,48 @@ func (rds *MysqlFeed) DeleteMatchOdds(matchID int64) {
 
 	var keysPattern []string
 
-	rds.DeleteAllMarkets(1, matchID)
-	rds.DeleteAllMarkets(3, matchID)
+	liveDeleted, _ := rds.deleteAllMarkets(1, matchID)
+	prematchDeleted, _ := rds.deleteAllMarkets(3, matchID)
+
+	deleted := liveDeleted + prematchDeleted
 
-	stasKey := fmt.Sprintf("fixture-stats:%d", matchID)
+	stasKey := rds.Keys.FixtureStatus(matchID)
 	keysPattern = append(keysPattern, stasKey)
 
-	matchPriorityKey := fmt.Sprintf("match-priority:%d", matchID)
+	matchPriorityKey := rds.Keys.MatchPriority(matchID)
 	keysPattern = append(keysPattern, matchPriorityKey)
 
 	// delete match date
-	matchDateKeys := fmt.Sprintf("match-date:%d", matchID)
+	matchDateKeys := rds.Keys.MatchDate(matchID)
 	keysPattern = append(keysPattern, matchDateKeys)
 
-	sportsKey := fmt.Sprintf("sport-id:%d", matchID)
+	sportsKey := rds.Keys.SportID(matchID)
 	keysPattern = append(keysPattern, sportsKey)
 
 	for _, key := range keysPattern {
 
 		if strings.Contains(key, "*") {
 
-			utils.DeleteKeysByPattern(rds.RedisClient, key)
+			count, _ := utils.CountDeleteKeysByPattern(rds.context(), rds.RedisClient, key)
+			deleted += count
 
 		} else {
 
-			utils.DeleteRedisKey(rds.RedisClient, key)
+			count, _ := utils.DeleteRedisKeys(rds.context(), rds.RedisClient, key)
+			deleted += count
 
 		}
 	}
 
+	rds.Audit.Record("DeleteMatchOdds", map[string]interface{}{"match_id": matchID}, deleted, nil)
+	rds.matchChanged(0, matchID, changelog.Entry{Resync: true})
+
 }
 
 // GetDefaultMarketID gets the default marketID for a particular sportID
 func (rds *MysqlFeed) GetDefaultMarketID(matchID, sportID int64) int64 {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 	dbUtils.SetQuery("SELECT default_market FROM match_odds_details WHERE match_id = ? ")
 	dbUtils.SetParams(matchID)
 
@@ -887,7 +1174,7 @@ func (rds *MysqlFeed) GetDefaultMarketID(matchID, sportID int64) int64 {
 
 func (rds *MysqlFeed) GetProducerStatus(producerID int64) int64 {
 
-	dbUtils := goutils.Db{DB: rds.DB, Context: context.TODO()}
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
 	query := "SELECT producer_status " +
 		" FROM producer " +
 		" WHERE producer_id = ? "
@@ -913,9 +1200,9 @@ func (rds *MysqlFeed) GetFixtureStatus(matchID int64) models.FixtureStatus {
 
 	market := new(models.FixtureStatus)
 
-	redisKey := fmt.Sprintf("fixture-stats:%d", matchID)
+	redisKey := rds.Keys.FixtureStatus(matchID)
 
-	data, _ := utils.GetRedisKey(rds.RedisClient, redisKey)
+	data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisKey)
 	if len(data) == 0 {
 
 		rds.RequestMatchTime(matchID)
@@ -945,16 +1232,23 @@ func (rds *MysqlFeed) GetFixtureStatus(matchID int64) models.FixtureStatus {
 // SetFixtureStatus sets fixture status for the supplied matchID
 func (rds *MysqlFeed) SetFixtureStatus(matchID int64, fx models.FixtureStatus) error {
 
-	redisKey := fmt.Sprintf("fixture-stats:%d", matchID)
+	redisKey := rds.Keys.FixtureStatus(matchID)
 
 	js, _ := json.Marshal(fx)
 
-	err := utils.SetRedisKey(rds.RedisClient, redisKey, string(js))
+	err := utils.SetRedisKey(rds.context(), rds.RedisClient, redisKey, string(js))
 	if err != nil {
 
 		log.Printf("error setting redis key %s | %s", redisKey, err.Error())
+
+	} else {
+
+		// the fixture status is shared by all producers of the match
+		rds.matchChanged(0, matchID, changelog.Entry{})
 	}
 
+	rds.Audit.Record("SetFixtureStatus", map[string]interface{}{"match_id": matchID, "fixture_status": fx}, 1, err)
+
 	return err
 
 }
@@ -0,0 +1,29 @@
+package mysqlfeeds
+
+import (
+	"log"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// checkOverround flags received markets whose overround is out of the configured range,
+// flagged markets are logged and published to market_overround as a data quality signal
+func (rds *MysqlFeed) checkOverround(odds models.OddsChange) {
+
+	for _, m := range rds.OverroundThresholds.CheckMarkets(odds.Markets) {
+
+		log.Printf("Producer %d | match %d | market %d:%s | overround %.4f out of range", odds.ProducerID, odds.MatchID, m.MarketID, m.Specifier, m.Overround)
+
+		utils.PublishToNats(rds.NatsClient, "market_overround", map[string]interface{}{
+			"match_id":        odds.MatchID,
+			"producer_id":     odds.ProducerID,
+			"sport_id":        odds.SportID,
+			"market_id":       m.MarketID,
+			"specifier":       m.Specifier,
+			"book_percentage": m.BookPercentage,
+			"overround":       m.Overround,
+		})
+	}
+
+}
@@ -0,0 +1,85 @@
+package mysqlfeeds
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// GetMatchVersion gets the sequence of the match, the sequence is incremented every time the markets or the fixture
+// status of the match change and is 0 for a match that never changed
+func (rds *MysqlFeed) GetMatchVersion(matchID int64) int64 {
+
+	data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, rds.Keys.MatchVersion(matchID))
+	sequence, _ := strconv.ParseInt(data, 10, 64)
+	return sequence
+}
+
+// GetAllMarketsWithSequence gets all markets for a particular matchID and the sequence of the match. The sequence
+// is saved in redis after the markets are committed and is read before the markets, the markets are never older
+// than the returned sequence
+func (rds *MysqlFeed) GetAllMarketsWithSequence(producerID, matchID int64) ([]models.Market, int64) {
+
+	sequence := rds.GetMatchVersion(matchID)
+	return rds.GetAllMarkets(producerID, matchID), sequence
+}
+
+// GetMarketsIfChanged gets all markets for a particular matchID when the sequence of the match is not sinceSequence,
+// changed is false and no markets are read when the match did not change since the supplied sequence
+func (rds *MysqlFeed) GetMarketsIfChanged(producerID, matchID, sinceSequence int64) (markets []models.Market, sequence int64, changed bool) {
+
+	sequence = rds.GetMatchVersion(matchID)
+	if sequence == sinceSequence {
+
+		return nil, sequence, false
+	}
+
+	return rds.GetAllMarkets(producerID, matchID), sequence, true
+}
+
+// GetChangesSince gets the markets of a particular matchID changed after sinceSequence. FullResync is set and all
+// markets are returned when the change log of the match does not reach back to sinceSequence
+func (rds *MysqlFeed) GetChangesSince(producerID, matchID, sinceSequence int64) models.MatchChanges {
+
+	if sinceSequence > 0 && rds.GetMatchVersion(matchID) == sinceSequence {
+
+		return models.MatchChanges{MatchID: matchID, ProducerID: producerID, Sequence: sinceSequence}
+	}
+
+	markets, sequence := rds.GetAllMarketsWithSequence(producerID, matchID)
+	entries := changelog.Read(rds.context(), rds.RedisClient, rds.Keys, matchID)
+
+	return changelog.Changes(entries, matchID, producerID, sinceSequence, sequence, markets)
+}
+
+// matchChanged increments the sequence of the match, logs the change and publishes it to odds_invalidation,
+// used by changes whose writes are not saved together with the sequence e.g deletes
+func (rds *MysqlFeed) matchChanged(producerID, matchID int64, change changelog.Entry) {
+
+	rds.publishInvalidation(producerID, matchID, rds.incrementSequence(matchID, change))
+}
+
+// incrementSequence increments the sequence of the match, logs the change and returns the incremented sequence
+func (rds *MysqlFeed) incrementSequence(matchID int64, change changelog.Entry) int64 {
+
+	sequence, err := utils.IncrRedisSequence(rds.context(), rds.RedisClient, rds.Keys.MatchVersion(matchID), constants.MatchVersionExpiry)
+	if err != nil {
+
+		log.Printf("error incrementing sequence of match %d | %s", matchID, err.Error())
+		return 0
+	}
+
+	rds.logChange(matchID, sequence, change)
+	return sequence
+}
+
+// logChange adds the change saved with the sequence to the change log of the match
+func (rds *MysqlFeed) logChange(matchID, sequence int64, change changelog.Entry) {
+
+	change.Sequence = sequence
+	changelog.Push(rds.context(), rds.RedisClient, rds.Keys, matchID, change)
+}
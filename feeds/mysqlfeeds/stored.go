@@ -0,0 +1,134 @@
+package mysqlfeeds
+
+import (
+	"encoding/json"
+	"log"
+
+	goutils "github.com/mudphilo/go-utils"
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// GetStoredFixtureStatus gets the fixture status as stored for the supplied matchID without match time requests,
+// ok is false when no fixture status is stored
+func (rds *MysqlFeed) GetStoredFixtureStatus(matchID int64) (fx models.FixtureStatus, ok bool) {
+
+	redisKey := rds.Keys.FixtureStatus(matchID)
+
+	data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisKey)
+	if len(data) == 0 {
+
+		return fx, false
+	}
+
+	err := json.Unmarshal([]byte(data), &fx)
+	if err != nil {
+
+		log.Printf("%s | GetStoredFixtureStatus failed to unmarshall %s to JSON %s", redisKey, data, err.Error())
+		return fx, false
+	}
+
+	return fx, true
+}
+
+// SetStoredMarkets replaces the stored markets of the supplied matchID as they are, markets locked by traders
+// are written as well and nothing is merged with the markets stored before. The markets are replaced in one
+// transaction, a failed write leaves the markets stored before
+func (rds *MysqlFeed) SetStoredMarkets(producerID, matchID, sportID int64, markets []models.Market) error {
+
+	err := rds.replaceMarkets(producerID, matchID, sportID, markets)
+
+	// a failed commit may still have been applied, readers refetch the match either way
+	rds.matchChanged(producerID, matchID, changelog.Entry{ProducerID: producerID, Resync: true})
+
+	count := int64(0)
+	if err == nil {
+
+		count = int64(len(markets))
+	}
+
+	rds.Audit.Record("SetStoredMarkets", map[string]interface{}{"producer_id": producerID, "match_id": matchID, "markets": len(markets)}, count, err)
+
+	return err
+}
+
+// replaceMarkets deletes the markets of the match and saves the supplied markets and the producer of the match in one transaction
+func (rds *MysqlFeed) replaceMarkets(producerID, matchID, sportID int64, markets []models.Market) error {
+
+	dbUtils := goutils.Db{DB: rds.DB, Context: rds.context()}
+
+	err := dbUtils.StartTransaction()
+	if err != nil {
+
+		return err
+	}
+
+	table := "live_odds"
+
+	if producerID == 3 {
+
+		table = "odds"
+
+	}
+
+	_, err = dbUtils.DeleteWithContextTx(table, map[string]interface{}{"match_id": matchID})
+	if err != nil {
+
+		log.Printf("error deleting data from %s %s ", table, err.Error())
+		dbUtils.Rollback()
+		return err
+	}
+
+	for _, m := range markets {
+
+		for _, o := range m.Outcomes {
+
+			inserts := map[string]interface{}{
+				"sport_id":     sportID,
+				"match_id":     matchID,
+				"market_id":    m.MarketID,
+				"market_name":  m.MarketName,
+				"specifier":    m.Specifier,
+				"status":       m.Status,
+				"status_name":  m.StatusName,
+				"outcome_id":   o.OutcomeID,
+				"outcome_name": o.OutcomeName,
+				"odds":         o.Odds,
+				"active":       o.Active,
+				"probability":  o.Probability,
+				"producer_id":  producerID,
+			}
+
+			_, err = dbUtils.UpsertWithContextTx(table, inserts, []string{"status", "status_name", "odds", "probability", "active"})
+			if err != nil {
+
+				log.Printf("error saving odds %s ", err.Error())
+				dbUtils.Rollback()
+				return err
+			}
+		}
+	}
+
+	details := map[string]interface{}{
+		"match_id":    matchID,
+		"producer_id": producerID,
+	}
+
+	_, err = dbUtils.UpsertWithContextTx("match_odds_details", details, []string{"producer_id"})
+	if err != nil {
+
+		log.Printf("error updating match_odds_details %s ", err.Error())
+		dbUtils.Rollback()
+		return err
+	}
+
+	err = dbUtils.Commit()
+	if err != nil {
+
+		log.Printf("error committing stored markets of %d %s ", matchID, err.Error())
+		return err
+	}
+
+	return nil
+}
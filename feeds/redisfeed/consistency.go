@@ -0,0 +1,487 @@
+package redisfeed
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/codec"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// IssueKind type of inconsistency found in the stored keys of a match
+type IssueKind string
+
+// MissingMarketKey market in the match blob has no market key
+const MissingMarketKey IssueKind = "missing_market_key"
+
+// OrphanMarketKey market key is neither in the match blob nor in the market-keys list
+const OrphanMarketKey IssueKind = "orphan_market_key"
+
+// BlobMismatch match blob is missing markets or has markets that differ from the market keys
+const BlobMismatch IssueKind = "blob_mismatch"
+
+// ListMismatch market-keys list does not list exactly the markets of the match
+const ListMismatch IssueKind = "list_mismatch"
+
+// MissingSportID match has markets but no sport-id key
+const MissingSportID IssueKind = "missing_sport_id"
+
+// MissingProducer match has markets but no active producer key
+const MissingProducer IssueKind = "missing_producer"
+
+// Issue inconsistency found in the stored keys of a match
+type Issue struct {
+	Kind       IssueKind `json:"kind"`
+	MatchID    int64     `json:"match_id"`
+	ProducerID int64     `json:"producer_id"`
+	Key        string    `json:"key"`
+	Detail     string    `json:"detail,omitempty"`
+	Repaired   bool      `json:"repaired"`
+}
+
+// ConsistencyReport result of a consistency check
+type ConsistencyReport struct {
+
+	// Matches number of matches checked
+	Matches int `json:"matches"`
+
+	// Keys number of keys scanned
+	Keys int `json:"keys"`
+
+	// Issues inconsistencies found
+	Issues []Issue `json:"issues"`
+}
+
+// Unrepaired gets the number of issues that were not repaired
+func (r *ConsistencyReport) Unrepaired() int {
+
+	total := 0
+
+	for _, i := range r.Issues {
+
+		if !i.Repaired {
+
+			total++
+		}
+	}
+
+	return total
+}
+
+// matchKeys keys stored for a match in one producer table
+type matchKeys struct {
+	blob    bool
+	list    bool
+	markets []string
+}
+
+// CheckConsistency compares the match blob, market-keys list and market keys of the supplied matches, all matches of the namespace
+// are checked when no matchID is supplied. When repair is set, market keys are treated as the most recent data:
+// missing market keys are written from the blob, orphan market keys are deleted, the blob and list are rebuilt from the market keys
+// and missing producer and sport-id keys are set from the producer table and fixture status. Repairs of a match are saved with
+// its sequence, logged to its change log and published to odds_invalidation so cached reads of the match are refreshed
+func (rds *RedisFeed) CheckConsistency(repair bool, matchIDs ...int64) *ConsistencyReport {
+
+	report := new(ConsistencyReport)
+
+	// active producer is set from the live table when a match has both live and prematch markets
+	producers := make(map[int64]int64)
+
+	for _, producerID := range []int64{3, 1} {
+
+		matches := rds.scanMatchKeys(producerID, report, matchIDs...)
+
+		var ids []int64
+
+		for matchID := range matches {
+
+			ids = append(ids, matchID)
+		}
+
+		sort.Slice(ids, func(i, j int) bool {
+
+			return ids[i] < ids[j]
+		})
+
+		for _, matchID := range ids {
+
+			if rds.checkMatch(producerID, matchID, matches[matchID], repair, report) {
+
+				producers[matchID] = producerID
+			}
+		}
+	}
+
+	report.Matches = len(producers)
+
+	for matchID, producerID := range producers {
+
+		rds.checkMatchKeys(matchID, producerID, repair, report)
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+
+		return report.Issues[i].MatchID < report.Issues[j].MatchID
+	})
+
+	if repair {
+
+		repaired := len(report.Issues) - report.Unrepaired()
+		rds.Audit.Record("RepairConsistency", map[string]interface{}{"match_ids": matchIDs}, int64(repaired), nil)
+	}
+
+	return report
+}
+
+// scanMatchKeys gets the keys of the supplied matches in the table grouped by matchID
+func (rds *RedisFeed) scanMatchKeys(producerID int64, report *ConsistencyReport, matchIDs ...int64) map[int64]*matchKeys {
+
+	var patterns []string
+
+	if len(matchIDs) == 0 {
+
+		patterns = append(patterns, rds.Keys.TablePattern(producerID))
+	}
+
+	for _, matchID := range matchIDs {
+
+		patterns = append(patterns, rds.Keys.Match(producerID, matchID), rds.Keys.MatchPattern(producerID, matchID))
+	}
+
+	matches := make(map[int64]*matchKeys)
+
+	for _, pattern := range patterns {
+
+		keys, _ := utils.ScanRedisKeys(rds.context(), rds.RedisClient, pattern)
+
+		for _, key := range keys {
+
+			// matchID or matchID:market-keys or matchID:market-marketID:specifier
+			matchID, remainder, ok := rds.Keys.ParseMatch(producerID, key)
+			if !ok {
+
+				continue
+			}
+
+			report.Keys++
+
+			match, ok := matches[matchID]
+			if !ok {
+
+				match = new(matchKeys)
+				matches[matchID] = match
+			}
+
+			switch {
+
+			case len(remainder) == 0:
+				match.blob = true
+
+			case key == rds.Keys.MarketKeys(producerID, matchID):
+				match.list = true
+
+			case strings.HasPrefix(remainder, "market-"):
+				match.markets = append(match.markets, key)
+
+			}
+		}
+	}
+
+	return matches
+}
+
+// checkMatch checks the blob, list and market keys of a match, returns true if the match has markets after the check
+func (rds *RedisFeed) checkMatch(producerID, matchID int64, keys *matchKeys, repair bool, report *ConsistencyReport) bool {
+
+	keyName := rds.Keys.Match(producerID, matchID)
+	listKey := rds.Keys.MarketKeys(producerID, matchID)
+
+	issue := func(kind IssueKind, key, detail string) int {
+
+		report.Issues = append(report.Issues, Issue{Kind: kind, MatchID: matchID, ProducerID: producerID, Key: key, Detail: detail})
+		return len(report.Issues) - 1
+	}
+
+	// orphan market keys to delete and keys to write when repairing, with the issues they repair
+	var deletes []string
+	var deleteIssues []int
+
+	writes := make(map[string]string)
+	var writeIssues []int
+
+	// markets in the blob by market key, in blob order
+	var blobOrder []string
+	blob := make(map[string]models.Market)
+
+	invalidBlob := false
+
+	if keys.blob {
+
+		var markets []models.Market
+
+		data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, keyName)
+
+		err := decodeMarkets(data, &markets)
+		if err != nil {
+
+			log.Printf("CheckConsistency failed to unmarshall %s to JSON %s", keyName, err.Error())
+			invalidBlob = true
+		}
+
+		for _, m := range markets {
+
+			key := rds.Keys.Market(producerID, matchID, m.MarketID, m.Specifier)
+
+			if _, ok := blob[key]; !ok {
+
+				blobOrder = append(blobOrder, key)
+			}
+
+			blob[key] = m
+		}
+	}
+
+	// markets listed in the market-keys list
+	var list []string
+
+	if keys.list {
+
+		data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, listKey)
+
+		err := json.Unmarshal([]byte(data), &list)
+		if err != nil {
+
+			log.Printf("CheckConsistency failed to unmarshall %s to JSON %s", listKey, err.Error())
+		}
+	}
+
+	// markets stored in market keys
+	stored := make(map[string]models.Market)
+
+	values, _ := utils.GetRedisKeys(rds.context(), rds.RedisClient, keys.markets...)
+
+	for i, key := range keys.markets {
+
+		var m models.Market
+
+		err := codec.DecodeMarket([]byte(values[i]), &m)
+		if err != nil {
+
+			log.Printf("CheckConsistency failed to unmarshall %s to JSON %s", key, err.Error())
+			continue
+		}
+
+		stored[key] = m
+	}
+
+	// markets of the match are the markets in the blob followed by markets only in the list
+	valid := append([]string(nil), blobOrder...)
+
+	for _, key := range list {
+
+		if _, ok := blob[key]; !ok && !contains(valid, key) {
+
+			valid = append(valid, key)
+		}
+	}
+
+	for _, key := range keys.markets {
+
+		if contains(valid, key) {
+
+			continue
+		}
+
+		i := issue(OrphanMarketKey, key, "")
+
+		if repair {
+
+			deletes = append(deletes, key)
+			deleteIssues = append(deleteIssues, i)
+		}
+	}
+
+	// market keys are treated as the most recent data of a market that differs from the blob
+	var markets []models.Market
+	var marketKeys []string
+
+	blobChanged := invalidBlob || (!keys.blob && len(valid) > 0)
+
+	for _, key := range valid {
+
+		m, ok := stored[key]
+		if !ok {
+
+			bm, inBlob := blob[key]
+			if !inBlob {
+
+				// listed market without blob data or market key, it can not be recovered
+				blobChanged = true
+				continue
+			}
+
+			i := issue(MissingMarketKey, key, "")
+
+			if repair {
+
+				js, _ := rds.Codec.EncodeMarket(bm)
+				writes[key] = string(js)
+				writeIssues = append(writeIssues, i)
+			}
+
+			m = bm
+
+		} else if bm, inBlob := blob[key]; !inBlob || !reflect.DeepEqual(bm, m) {
+
+			blobChanged = true
+		}
+
+		markets = append(markets, m)
+		marketKeys = append(marketKeys, key)
+	}
+
+	if blobChanged {
+
+		i := issue(BlobMismatch, keyName, fmt.Sprintf("blob has %d markets, market keys have %d markets", len(blob), len(markets)))
+
+		if repair {
+
+			js, _ := rds.Codec.EncodeMarkets(markets)
+			writes[keyName] = string(js)
+			writeIssues = append(writeIssues, i)
+		}
+	}
+
+	if !sameKeys(list, marketKeys) {
+
+		i := issue(ListMismatch, listKey, fmt.Sprintf("list has %d keys, match has %d markets", len(list), len(marketKeys)))
+
+		if repair {
+
+			js, _ := json.Marshal(marketKeys)
+			writes[listKey] = string(js)
+			writeIssues = append(writeIssues, i)
+		}
+	}
+
+	if len(deletes) > 0 {
+
+		_, err := utils.DeleteRedisKeys(rds.context(), rds.RedisClient, deletes...)
+		for _, i := range deleteIssues {
+
+			report.Issues[i].Repaired = err == nil
+		}
+
+		if err == nil && len(writes) == 0 {
+
+			rds.matchChanged(producerID, matchID, changelog.Entry{ProducerID: producerID, Resync: true})
+		}
+	}
+
+	if len(writes) > 0 {
+
+		sequence, err := rds.saveWithSequence(matchID, writes)
+		for _, i := range writeIssues {
+
+			report.Issues[i].Repaired = err == nil
+		}
+
+		if err == nil {
+
+			rds.logChange(matchID, sequence, changelog.Entry{ProducerID: producerID, Resync: true})
+			rds.publishInvalidation(producerID, matchID, sequence)
+		}
+	}
+
+	return len(markets) > 0
+}
+
+// checkMatchKeys checks the sport-id and active producer keys of a match with markets
+func (rds *RedisFeed) checkMatchKeys(matchID, producerID int64, repair bool, report *ConsistencyReport) {
+
+	repaired := false
+
+	producerKey := rds.Keys.Producer(matchID)
+
+	if exists, err := utils.RedisKeyExists(rds.context(), rds.RedisClient, producerKey); err == nil && !exists {
+
+		i := Issue{Kind: MissingProducer, MatchID: matchID, ProducerID: producerID, Key: producerKey}
+
+		if repair {
+
+			i.Repaired = rds.setProducerID(matchID, producerID) == nil
+			repaired = repaired || i.Repaired
+		}
+
+		report.Issues = append(report.Issues, i)
+	}
+
+	sportsKey := rds.Keys.SportID(matchID)
+
+	if exists, err := utils.RedisKeyExists(rds.context(), rds.RedisClient, sportsKey); err == nil && !exists {
+
+		i := Issue{Kind: MissingSportID, MatchID: matchID, ProducerID: producerID, Key: sportsKey}
+
+		// the sportID can only be recovered from a stored fixture status
+		if repair {
+
+			var fx models.FixtureStatus
+
+			data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, rds.Keys.FixtureStatus(matchID))
+			if json.Unmarshal([]byte(data), &fx) == nil && fx.SportID > 0 {
+
+				i.Repaired = utils.SetRedisKey(rds.context(), rds.RedisClient, sportsKey, fmt.Sprintf("%d", fx.SportID)) == nil
+				repaired = repaired || i.Repaired
+
+			} else {
+
+				i.Detail = "no fixture status to recover the sportID from"
+			}
+		}
+
+		report.Issues = append(report.Issues, i)
+	}
+
+	if repaired {
+
+		rds.matchChanged(producerID, matchID, changelog.Entry{ProducerID: producerID, Resync: true})
+	}
+}
+
+func contains(keys []string, key string) bool {
+
+	for _, k := range keys {
+
+		if k == key {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameKeys checks both lists have the same keys in any order
+func sameKeys(a, b []string) bool {
+
+	if len(a) != len(b) {
+
+		return false
+	}
+
+	for _, k := range a {
+
+		if !contains(b, k) {
+
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,35 @@
+package redisfeed
+
+import (
+	"fmt"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/oddsformat"
+)
+
+// prepareMarkets applies trader overrides and the configured odds format to markets read from storage
+func (rds *RedisFeed) prepareMarkets(matchID int64, markets []models.Market) []models.Market {
+
+	return oddsformat.FormatMarkets(rds.Overrides.ApplyMarkets(matchID, markets), rds.OddsFormat)
+
+}
+
+// prepareMarket applies trader overrides and the configured odds format to a market read from storage
+func (rds *RedisFeed) prepareMarket(matchID int64, market *models.Market) *models.Market {
+
+	return oddsformat.FormatMarket(rds.Overrides.ApplyMarket(matchID, market), rds.OddsFormat)
+
+}
+
+// prepareOdds applies trader overrides and the configured odds format to odds read from storage
+func (rds *RedisFeed) prepareOdds(odds *models.OddsDetails) *models.OddsDetails {
+
+	return oddsformat.FormatOddsDetails(rds.Overrides.ApplyOdds(odds), rds.OddsFormat)
+
+}
+
+// ConfigVersion gets the version of the configuration the reads depend on, the odds format and the translations version
+func (rds *RedisFeed) ConfigVersion() string {
+
+	return fmt.Sprintf("%s-%s", rds.OddsFormat, rds.Translations.Version())
+}
@@ -1,20 +1,27 @@
 package redisfeed
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/go-redis/redis"
 	goutils "github.com/mudphilo/go-utils"
 	nats "github.com/nats-io/nats.go"
-	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/analytics"
+	"github.com/touchvas/odds-sdk/v2/audit"
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/codec"
 	"github.com/touchvas/odds-sdk/v2/constants/sport_event_status"
 	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/keyspace"
 	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/oddsformat"
+	"github.com/touchvas/odds-sdk/v2/overrides"
+	"github.com/touchvas/odds-sdk/v2/translations"
 	"github.com/touchvas/odds-sdk/v2/utils"
 	"log"
 	"os"
+	"sort"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 )
@@ -23,28 +30,135 @@ var NameSpace = os.Getenv("ODDS_FEED_NAMESPACE")
 
 type RedisFeed struct {
 	feeds.Feed
-	RedisClient *redis.Client
-	NatsClient  *nats.Conn
+	RedisClient         utils.Redis
+	NatsClient          *nats.Conn
+	Translations        *translations.Store
+	OddsFormat          oddsformat.Format
+	OverroundThresholds *analytics.OverroundThresholds
+	Overrides           *overrides.Store
+	Audit               *audit.Logger
+	Keys                keyspace.Keyspace
+
+	// Codec encodes the stored markets, markets stored by any codec are read
+	Codec codec.Codec
+
+	// ctx bounds the redis calls of the feed, see WithContext
+	ctx context.Context
+}
+
+// Config settings of a redis feed instance
+type Config struct {
+
+	// Namespace feed namespace of the match and market keys
+	Namespace string
+
+	// KeyPrefix tenant prefix of all keys, required when tenants share a redis database
+	KeyPrefix string
+
+	// Redis connection settings, not used when RedisClient is set
+	Redis utils.RedisConfig
+
+	// RedisClient optional redis client shared with other instances
+	RedisClient utils.Redis
+
+	// NatsClient optional nats connection shared with other instances, connects with FEEDS_SERVICE_NATS_URI when not set
+	NatsClient *nats.Conn
+
+	// Codec encodes the stored markets, defaults to JSON
+	Codec codec.Codec
 }
 
 var instance *RedisFeed
 var once sync.Once
 
+// ConfigFromEnv gets the settings of the default instance from the environment
+func ConfigFromEnv() Config {
+
+	return Config{
+		Namespace: NameSpace,
+		Redis:     utils.RedisConfigFromEnv(),
+		Codec:     codec.FromEnv(),
+	}
+}
+
+// GetFeedsInstance gets the default instance configured from the environment
 func GetFeedsInstance() *RedisFeed {
 
 	once.Do(func() {
 
 		fmt.Println("Creating Redis Feeds instance")
-		instance = &RedisFeed{
-			RedisClient: utils.RedisClient(),
-			NatsClient:  utils.GetNatsConnection(),
-		}
+		instance = New(ConfigFromEnv())
 	})
 
 	return instance
 
 }
 
+// New creates a redis feed instance with the supplied settings, use it to run feeds of several namespaces in one process
+func New(cfg Config) *RedisFeed {
+
+	redisClient := cfg.RedisClient
+	if redisClient == nil {
+
+		redisClient = utils.NewRedisClient(cfg.Redis)
+	}
+
+	natsClient := cfg.NatsClient
+	if natsClient == nil {
+
+		natsClient = utils.GetNatsConnection()
+	}
+
+	marketsCodec := cfg.Codec
+	if marketsCodec == nil {
+
+		marketsCodec = codec.JSON
+	}
+
+	keys := keyspace.New(cfg.Namespace).WithPrefix(cfg.KeyPrefix).WithHashTags(cfg.Redis.HashTags)
+
+	translationsStore := translations.NewStore(redisClient)
+	translationsStore.Keys = keys
+
+	overridesStore := overrides.NewStore(redisClient)
+	overridesStore.Keys = keys
+	overridesStore.NatsClient = natsClient
+
+	return &RedisFeed{
+		RedisClient:         redisClient,
+		NatsClient:          natsClient,
+		Translations:        translationsStore,
+		OddsFormat:          oddsformat.FormatFromEnv(),
+		OverroundThresholds: analytics.ThresholdsFromEnv(),
+		Overrides:           overridesStore,
+		Audit:               audit.NewLogger("redis", keys.Name(), audit.SinksFromEnv(redisClient, nil)...),
+		Keys:                keys,
+		Codec:               marketsCodec,
+	}
+}
+
+// WithContext gets a copy of the feed whose redis calls are bound by the supplied context
+// e.g the deadline of a grpc request, the copy shares the clients of the feed
+func (rds *RedisFeed) WithContext(ctx context.Context) *RedisFeed {
+
+	feed := *rds
+	feed.ctx = ctx
+	feed.Translations = rds.Translations.WithContext(ctx)
+	feed.Overrides = rds.Overrides.WithContext(ctx)
+	return &feed
+}
+
+// context gets the context of the redis calls, background when the feed has no context
+func (rds *RedisFeed) context() context.Context {
+
+	if rds.ctx == nil {
+
+		return context.Background()
+	}
+
+	return rds.ctx
+}
+
 // OddsChange Update new odds change message
 func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 
@@ -58,16 +172,8 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 
 	defaultMarketsList := []string{"1", "186", "219", "340", "251"}
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if odds.ProducerID == 1 || odds.ProducerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, odds.MatchID)
+	keyName := rds.Keys.Match(odds.ProducerID, odds.MatchID)
 
 	if odds.MatchID == DebugMatchID {
 
@@ -95,96 +201,53 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 		return 0, nil
 	}
 
+	// flag markets with overround out of range as a data quality signal
+	rds.checkOverround(odds)
+
+	// markets locked by traders are not updated until the lock is cleared
+	odds.Markets = rds.Overrides.FilterLocked(odds.MatchID, odds.Markets)
+	if len(odds.Markets) == 0 {
+
+		return 0, nil
+	}
+
+	// all writes of the match are saved in one transaction, the keys share the match hash tag on a cluster
+	writes := make(map[string]string)
+
 	// get existing data
 	// Read a record
 
 	keyExists := rds.keyExist(keyName)
 
-	matchKeys := fmt.Sprintf(constants.KeysFieldTemplate, keyName)
+	matchKeys := rds.Keys.MarketKeys(odds.ProducerID, odds.MatchID)
 
 	// set the active producer for this match
-	rds.SetProducerID(odds.MatchID, odds.ProducerID)
+	rds.setProducerID(odds.MatchID, odds.ProducerID)
 
 	// create new keys if it does not exist, this occurs the first time we receive odds for a match
 	//or the first odds after a match transitions from prematch to live (producerID changes)
 	if !keyExists {
 
-		var keys []string
-
 		if odds.MatchID == DebugMatchID {
 
 			log.Printf("keyExists %s does not exist ", keyName)
 
 		}
 
-		// loop through all the received markets
-		for _, m := range odds.Markets {
-
-			cacheValue, _ := json.Marshal(m)
-
-			specifierKey := m.Specifier
-			if len(specifierKey) == 0 {
-
-				// if specifier is empty, to avoid using empty in between key name, we use no-specifier
-				specifierKey = constants.EmptySpecifier
-			}
-
-			// namespace:table:match-matchID:market-marketID:specifierKey
-			redisMarketKey := fmt.Sprintf("%s:market-%d:%s", keyName, m.MarketID, specifierKey)
-			if odds.MatchID == DebugMatchID {
-
-				log.Printf("Saving data to %s ", redisMarketKey)
-
-			}
-
-			// save each market data as redis keys
-			// this will be used on the homepage or when gettings odds via GRPC
-			utils.SetRedisKey(rds.RedisClient, redisMarketKey, string(cacheValue))
-
-			// keep a record of all created market keys
-			keys = append(keys, redisMarketKey)
-
-			if defaultMarketID == 0 && len(m.Outcomes) > 0 {
-
-				if goutils.Contains(defaultMarketsList, fmt.Sprintf("%d", m.MarketID)) {
-
-					defaultMarketID = m.MarketID
-
-				}
-
-			}
-
-			if len(m.Outcomes) > 0 && (m.Status == 0 || m.Status == 5) {
-
-				uniqueTotalMarkets[m.MarketID] = 1
-			}
-
-		}
-
-		// save the entire markets into one key, this will be used in get more/detailed/all market endpoint
-		jsonValue, _ := json.Marshal(odds.Markets)
-		utils.SetRedisKey(rds.RedisClient, keyName, string(jsonValue))
+		writes = rds.newMatchWrites(odds.ProducerID, odds.MatchID, odds.SportID, odds.Markets)
 
-		// save all the market keys for easier retrieval of data later
-		jsonValue, _ = json.Marshal(keys)
-		utils.SetRedisKey(rds.RedisClient, matchKeys, string(jsonValue))
-
-		if defaultMarketID > 0 {
-
-			defaultMarketKey := fmt.Sprintf("%s:default-market-id:%d", NameSpace, odds.MatchID)
-			utils.SetRedisKey(rds.RedisClient, defaultMarketKey, fmt.Sprintf("%d", defaultMarketID))
+		sequence, err := rds.saveWithSequence(odds.MatchID, writes)
+		if err != nil {
 
+			return 0, err
 		}
 
-		totalMarketsKey := fmt.Sprintf("%s:total-markets:%d", NameSpace, odds.MatchID)
-		utils.SetRedisKey(rds.RedisClient, totalMarketsKey, fmt.Sprintf("%d", len(uniqueTotalMarkets)))
-
-		sportsKey := fmt.Sprintf("sport-id:%d", odds.MatchID)
-		utils.SetRedisKey(rds.RedisClient, sportsKey, fmt.Sprintf("%d", odds.SportID))
+		rds.logChange(odds.MatchID, sequence, changelog.Entry{ProducerID: odds.ProducerID, Markets: changelog.MarketKeys(odds.Markets)})
+		rds.publishInvalidation(odds.ProducerID, odds.MatchID, sequence)
 
 		if DebugMatchID == odds.MatchID {
 
-			log.Printf("all keys %s ", string(jsonValue))
+			log.Printf("all keys %s ", writes[matchKeys])
 		}
 
 		ttl := time.Now().UnixMilli() - odds.BetradarTimestamp
@@ -213,7 +276,7 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 	var keys []string
 
 	// get all existing market keys for this matchID
-	keysListAsString, _ := utils.GetRedisKey(rds.RedisClient, matchKeys)
+	keysListAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, matchKeys)
 	err := json.Unmarshal([]byte(keysListAsString), &keys)
 	if err != nil {
 
@@ -232,15 +295,8 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 
 	for _, m := range odds.Markets {
 
-		specifierKey := m.Specifier
-		if len(specifierKey) == 0 {
-
-			// if specifier is empty, to avoid using empty in between key name, we use no-specifier
-			specifierKey = constants.EmptySpecifier
-		}
-
 		// namespace:table:match-matchID:market-marketID:specifierKey
-		redisMarketKey := fmt.Sprintf("%s:market-%d:%s", keyName, m.MarketID, specifierKey)
+		redisMarketKey := rds.Keys.Market(odds.ProducerID, odds.MatchID, m.MarketID, m.Specifier)
 		if odds.MatchID == DebugMatchID {
 
 			log.Printf("saving data to %s ", redisMarketKey)
@@ -254,11 +310,11 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 			// check if the market exists
 			// only update markets that exists
 
-			marketDataAsString, _ := utils.GetRedisKey(rds.RedisClient, redisMarketKey)
+			marketDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisMarketKey)
 			if len(marketDataAsString) > 0 {
 
 				var market models.Market
-				err = json.Unmarshal([]byte(marketDataAsString), &market)
+				err = codec.DecodeMarket([]byte(marketDataAsString), &market)
 				if err != nil {
 
 					log.Printf("error unmarshaling %s to models.Market %s ", marketDataAsString, err.Error())
@@ -270,8 +326,8 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 				market.StatusName = m.StatusName
 
 				// save market to redis
-				jsonValue, _ := json.Marshal(market)
-				utils.SetRedisKey(rds.RedisClient, redisMarketKey, string(jsonValue))
+				jsonValue, _ := rds.Codec.EncodeMarket(market)
+				writes[redisMarketKey] = string(jsonValue)
 
 				// save market to keys map
 				uniqueKeys[redisMarketKey] = market
@@ -286,8 +342,8 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 		//currentMarkets[redisMarketKey] = m
 
 		// save market to redis
-		jsonValue, _ := json.Marshal(m)
-		utils.SetRedisKey(rds.RedisClient, redisMarketKey, string(jsonValue))
+		jsonValue, _ := rds.Codec.EncodeMarket(m)
+		writes[redisMarketKey] = string(jsonValue)
 
 		// save market to keys map
 		uniqueKeys[redisMarketKey] = m
@@ -335,11 +391,11 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 
 		}
 
-		marketDataAsString, _ := utils.GetRedisKey(rds.RedisClient, k)
+		marketDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, k)
 		if len(marketDataAsString) > 0 {
 
 			var market models.Market
-			err = json.Unmarshal([]byte(marketDataAsString), &market)
+			err = codec.DecodeMarket([]byte(marketDataAsString), &market)
 			if err != nil {
 
 				log.Printf("error unmarshaling %s to models.Market %s ", marketDataAsString, err.Error())
@@ -355,15 +411,15 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 	// lets do market ordering,
 	// allMarketsData = rds.orderByPriority(allMarketsData, priorityList1)
 
-	jsonValue, _ := json.Marshal(allMarketsData)
-	utils.SetRedisKey(rds.RedisClient, keyName, string(jsonValue))
+	jsonValue, _ := rds.Codec.EncodeMarkets(allMarketsData)
+	writes[keyName] = string(jsonValue)
 	if DebugMatchID == odds.MatchID {
 
 		log.Printf("all markets %s ", string(jsonValue))
 	}
 
 	jsonValue, _ = json.Marshal(allKeys)
-	utils.SetRedisKey(rds.RedisClient, matchKeys, string(jsonValue))
+	writes[matchKeys] = string(jsonValue)
 
 	if DebugMatchID == odds.MatchID {
 
@@ -372,16 +428,25 @@ func (rds *RedisFeed) OddsChange(odds models.OddsChange) (int, error) {
 
 	if defaultMarketID > 0 {
 
-		defaultMarketKey := fmt.Sprintf("%s:default-market-id:%d", NameSpace, odds.MatchID)
-		utils.SetRedisKey(rds.RedisClient, defaultMarketKey, fmt.Sprintf("%d", defaultMarketID))
+		defaultMarketKey := rds.Keys.DefaultMarket(odds.MatchID)
+		writes[defaultMarketKey] = fmt.Sprintf("%d", defaultMarketID)
 
 	}
 
-	totalMarketsKey := fmt.Sprintf("%s:total-markets:%d", NameSpace, odds.MatchID)
-	utils.SetRedisKey(rds.RedisClient, totalMarketsKey, fmt.Sprintf("%d", len(uniqueTotalMarkets)))
+	totalMarketsKey := rds.Keys.TotalMarkets(odds.MatchID)
+	writes[totalMarketsKey] = fmt.Sprintf("%d", len(uniqueTotalMarkets))
 
-	sportsKey := fmt.Sprintf("sport-id:%d", odds.MatchID)
-	utils.SetRedisKey(rds.RedisClient, sportsKey, fmt.Sprintf("%d", odds.SportID))
+	sportsKey := rds.Keys.SportID(odds.MatchID)
+	writes[sportsKey] = fmt.Sprintf("%d", odds.SportID)
+
+	sequence, err := rds.saveWithSequence(odds.MatchID, writes)
+	if err != nil {
+
+		return 0, err
+	}
+
+	rds.logChange(odds.MatchID, sequence, changelog.Entry{ProducerID: odds.ProducerID, Markets: changelog.MarketKeys(odds.Markets)})
+	rds.publishInvalidation(odds.ProducerID, odds.MatchID, sequence)
 
 	ttl := time.Now().UnixMilli() - odds.BetradarTimestamp
 
@@ -410,16 +475,8 @@ func (rds *RedisFeed) BetStop(producerID, matchID, status int64, statusName stri
 
 	// log.Printf("Bet Stop | %d | producerID %d ", matchID, producerID)
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if producerID == 1 || producerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	keyName := rds.Keys.Match(producerID, matchID)
 
 	keyExists := rds.keyExist(keyName)
 	if !keyExists {
@@ -430,8 +487,8 @@ func (rds *RedisFeed) BetStop(producerID, matchID, status int64, statusName stri
 	matchData := new([]models.Market)
 
 	// get all markets for this matchID and suspend them
-	matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, keyName)
-	err := json.Unmarshal([]byte(matchDataAsString), matchData)
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, keyName)
+	err := decodeMarkets(matchDataAsString, matchData)
 	if err != nil {
 
 		log.Printf("BetStop - failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
@@ -439,10 +496,13 @@ func (rds *RedisFeed) BetStop(producerID, matchID, status int64, statusName stri
 	}
 
 	// set the active producer for this match
-	rds.SetProducerID(matchID, producerID)
+	rds.setProducerID(matchID, producerID)
 
 	markets := *matchData
 
+	// all writes of the match are saved in one transaction, the keys share the match hash tag on a cluster
+	writes := make(map[string]string)
+
 	// loop through each market and update the status with the status received from betstop
 	for i, m := range markets {
 
@@ -450,25 +510,27 @@ func (rds *RedisFeed) BetStop(producerID, matchID, status int64, statusName stri
 		m.StatusName = statusName
 		markets[i] = m
 
-		specifierKey := m.Specifier
-		if len(specifierKey) == 0 {
-
-			specifierKey = constants.EmptySpecifier
-		}
-
-		// update data saved under market keys with updated data
 		// namespace:table:match-matchID:market-marketID:specifierKey
-		redisMarketKey := fmt.Sprintf("%s:market-%d:%s", keyName, m.MarketID, specifierKey)
+		redisMarketKey := rds.Keys.Market(producerID, matchID, m.MarketID, m.Specifier)
 
 		// replace existing data
-		jsonValue, _ := json.Marshal(m)
-		utils.SetRedisKey(rds.RedisClient, redisMarketKey, string(jsonValue))
+		jsonValue, _ := rds.Codec.EncodeMarket(m)
+		writes[redisMarketKey] = string(jsonValue)
 
 	}
 
 	// replace existing data
-	jsonValue, _ := json.Marshal(markets)
-	utils.SetRedisKey(rds.RedisClient, keyName, string(jsonValue))
+	jsonValue, _ := rds.Codec.EncodeMarkets(markets)
+	writes[keyName] = string(jsonValue)
+
+	sequence, err := rds.saveWithSequence(matchID, writes)
+	if err != nil {
+
+		return err
+	}
+
+	rds.logChange(matchID, sequence, changelog.Entry{ProducerID: producerID, AllMarkets: true})
+	rds.publishInvalidation(producerID, matchID, sequence)
 
 	// log time taken to process odds, we have to process within 2s
 
@@ -492,16 +554,8 @@ func (rds *RedisFeed) BetStop(producerID, matchID, status int64, statusName stri
 // GetAllMarkets gets all markets with odds for a particular matchID
 func (rds *RedisFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if producerID == 1 || producerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	keyName := rds.Keys.Match(producerID, matchID)
 
 	keyExists := rds.keyExist(keyName)
 
@@ -513,40 +567,73 @@ func (rds *RedisFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
 
 	markets := new([]models.Market)
 
-	matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, keyName)
-	err := json.Unmarshal([]byte(matchDataAsString), markets)
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, keyName)
+	err := decodeMarkets(matchDataAsString, markets)
 	if err != nil {
 
 		log.Printf("GetAllMarkets failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
 		return nil
 	}
 
-	return *markets
+	return rds.prepareMarkets(matchID, *markets)
 }
 
-// GetMarket gets market with odds for a particular matchID and marketID
-func (rds *RedisFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
+// GetStoredMarkets gets the markets as stored for a particular matchID, without overrides, formatting or odds recovery requests
+func (rds *RedisFeed) GetStoredMarkets(producerID, matchID int64) []models.Market {
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
+	// namespace:table:matchID
+	keyName := rds.Keys.Match(producerID, matchID)
 
-	if producerID == 1 || producerID == 4 {
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, keyName)
+	if len(matchDataAsString) == 0 {
 
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
+		return nil
 	}
 
-	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	var markets []models.Market
+
+	err := decodeMarkets(matchDataAsString, &markets)
+	if err != nil {
+
+		log.Printf("GetStoredMarkets failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
+		return nil
+	}
+
+	return markets
+}
 
-	var specifierKey = specifier
+// GetMatchIDs gets the matchIDs with stored markets for the supplied producer
+func (rds *RedisFeed) GetMatchIDs(producerID int64) []int64 {
 
-	if len(specifierKey) == 0 {
+	keys, _ := utils.ScanRedisKeys(rds.context(), rds.RedisClient, rds.Keys.TablePattern(producerID))
 
-		specifierKey = constants.EmptySpecifier
+	var matchIDs []int64
+
+	for _, key := range keys {
+
+		// namespace:table:matchID, market keys and market-keys lists have a suffix after the matchID
+		matchID, remainder, ok := rds.Keys.ParseMatch(producerID, key)
+		if !ok || len(remainder) > 0 {
+
+			continue
+		}
+
+		matchIDs = append(matchIDs, matchID)
 	}
 
+	sort.Slice(matchIDs, func(i, j int) bool {
+
+		return matchIDs[i] < matchIDs[j]
+	})
+
+	return matchIDs
+}
+
+// GetMarket gets market with odds for a particular matchID and marketID
+func (rds *RedisFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
+
 	// namespace:table:match-matchID:market-marketID:specifierKey
-	redisMarketKey := fmt.Sprintf("%s:market-%d:%s", keyName, marketID, specifierKey)
+	redisMarketKey := rds.Keys.Market(producerID, matchID, marketID, specifier)
 
 	// get existing data
 	// Read a record
@@ -559,7 +646,7 @@ func (rds *RedisFeed) GetMarket(producerID, matchID, marketID int64, specifier s
 
 	market := new(models.Market)
 
-	matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, redisMarketKey)
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisMarketKey)
 
 	if len(matchDataAsString) == 0 {
 
@@ -567,46 +654,26 @@ func (rds *RedisFeed) GetMarket(producerID, matchID, marketID int64, specifier s
 		return nil
 	}
 
-	err := json.Unmarshal([]byte(matchDataAsString), market)
+	err := codec.DecodeMarket([]byte(matchDataAsString), market)
 	if err != nil {
 
 		log.Printf("%s | GetMarket failed to unmarshall %s to JSON %s", redisMarketKey, matchDataAsString, err.Error())
 		return nil
 	}
 
-	return market
+	return rds.prepareMarket(matchID, market)
 
 }
 
 // GetOdds gets odds from quadruplets matchID, marketID , specifier and outcomeID
 func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID string) *models.OddsDetails {
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
 	producerID, _ := rds.GetProducerID(matchID)
 
-	if producerID == 1 || producerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
-	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
-
-	specifierKey := specifier
-
-	if len(specifierKey) == 0 {
-
-		specifierKey = constants.EmptySpecifier
-	}
-
 	// namespace:table:match-matchID:market-marketID:specifierKey
-	redisMarketKey := fmt.Sprintf("%s:market-%d:%s", keyName, marketID, specifierKey)
+	redisMarketKey := rds.Keys.Market(producerID, matchID, marketID, specifier)
 
-	sportsKey := fmt.Sprintf("sport-id:%d", matchID)
-	sportIDStr, _ := utils.GetRedisKey(rds.RedisClient, sportsKey)
-	sportID, _ := strconv.ParseInt(sportIDStr, 10, 64)
+	sportID := rds.GetSportID(matchID)
 
 	// get existing data
 	// Read a record
@@ -623,7 +690,7 @@ func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 
 					if v.OutcomeID == outcomeID {
 
-						return &models.OddsDetails{
+						return rds.prepareOdds(&models.OddsDetails{
 							SportID:     sportID,
 							MatchID:     matchID,
 							MarketID:    marketID,
@@ -640,7 +707,7 @@ func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 							Probability: v.Probability,
 							EventType:   "match",
 							EventPrefix: "sr",
-						}
+						})
 					}
 				}
 
@@ -655,8 +722,8 @@ func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 
 	market := new(models.Market)
 
-	matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, redisMarketKey)
-	err := json.Unmarshal([]byte(matchDataAsString), market)
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisMarketKey)
+	err := codec.DecodeMarket([]byte(matchDataAsString), market)
 	if err != nil {
 
 		log.Printf("GetOdds - failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
@@ -668,7 +735,7 @@ func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 
 		if v.OutcomeID == outcomeID {
 
-			return &models.OddsDetails{
+			return rds.prepareOdds(&models.OddsDetails{
 				SportID:     sportID,
 				MatchID:     matchID,
 				MarketID:    marketID,
@@ -685,7 +752,7 @@ func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 				Probability: v.Probability,
 				EventType:   "match",
 				EventPrefix: "sr",
-			}
+			})
 		}
 	}
 
@@ -695,16 +762,8 @@ func (rds *RedisFeed) GetOdds(matchID, marketID int64, specifier, outcomeID stri
 // GetAllMarketsOrderByList gets all markets with odds for a particular matchID order by the supplied list of markets
 func (rds *RedisFeed) GetAllMarketsOrderByList(producerID, matchID int64, marketOderList []models.MarketOrderList) []models.Market {
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if producerID == 1 || producerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	keyName := rds.Keys.Match(producerID, matchID)
 
 	keyExists := rds.keyExist(keyName)
 
@@ -717,8 +776,8 @@ func (rds *RedisFeed) GetAllMarketsOrderByList(producerID, matchID int64, market
 	markets := new([]models.Market)
 	var orderedMarkets, marketsInTheOrderedList, otherMarkets []models.Market
 
-	matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, keyName)
-	err := json.Unmarshal([]byte(matchDataAsString), markets)
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, keyName)
+	err := decodeMarkets(matchDataAsString, markets)
 	if err != nil {
 
 		log.Printf("GetAllMarketsOrderByList failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
@@ -772,23 +831,15 @@ func (rds *RedisFeed) GetAllMarketsOrderByList(producerID, matchID int64, market
 
 	}
 
-	return orderedMarkets
+	return rds.prepareMarkets(matchID, orderedMarkets)
 
 }
 
 // GetSpecifiedMarkets gets the specified markets with odds for a particular matchID order by the supplied list of markets
 func (rds *RedisFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList []models.MarketOrderList) []models.Market {
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if producerID == 1 || producerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	keyName := rds.Keys.Match(producerID, matchID)
 
 	keyExists := rds.keyExist(keyName)
 
@@ -801,8 +852,8 @@ func (rds *RedisFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList
 	markets := new([]models.Market)
 	var orderedMarkets, marketsInTheOrderedList []models.Market
 
-	matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, keyName)
-	err := json.Unmarshal([]byte(matchDataAsString), markets)
+	matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, keyName)
+	err := decodeMarkets(matchDataAsString, markets)
 	if err != nil {
 
 		log.Printf("GetSpecifiedMarkets failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
@@ -846,82 +897,121 @@ func (rds *RedisFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList
 
 	}
 
-	return orderedMarkets
+	return rds.prepareMarkets(matchID, orderedMarkets)
 
 }
 
 // DeleteAllMarkets deletes markets for the specified matchID
 func (rds *RedisFeed) DeleteAllMarkets(producerID, matchID int64) error {
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if producerID == 1 || producerID == 4 {
+	deleted, err := rds.deleteAllMarkets(producerID, matchID)
+	rds.Audit.Record("DeleteAllMarkets", map[string]interface{}{"producer_id": producerID, "match_id": matchID}, deleted, err)
+	rds.matchChanged(producerID, matchID, changelog.Entry{ProducerID: producerID, Resync: true})
+	return err
+}
 
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
+func (rds *RedisFeed) deleteAllMarkets(producerID, matchID int64) (int64, error) {
 
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	keyName := rds.Keys.Match(producerID, matchID)
 
 	keyExists := rds.keyExist(keyName)
 
 	if !keyExists {
 
-		return nil
+		return 0, nil
 	}
 
-	utils.DeleteRedisKey(rds.RedisClient, keyName)
-	utils.DeleteKeysByPattern(rds.RedisClient, fmt.Sprintf("%s:*", keyName))
+	deleted, _ := utils.DeleteRedisKeys(rds.context(), rds.RedisClient, keyName)
+	marketsDeleted, err := utils.CountDeleteKeysByPattern(rds.context(), rds.RedisClient, rds.Keys.MatchPattern(producerID, matchID))
 
-	return nil
+	return deleted + marketsDeleted, err
 }
 
-// DeleteAll deletes all feeds data
-func (rds *RedisFeed) DeleteAll() error {
+// DeleteAll deletes all feeds data, confirmation must be the token set in FEEDS_DELETE_ALL_TOKEN (see audit.Confirm).
+// Match sequences and change logs are kept and the sequence of every deleted match is incremented, so sequences and
+// ETags of a match never repeat and readers of the deleted matches are invalidated
+func (rds *RedisFeed) DeleteAll(confirmation string) error {
 
-	return utils.DeleteKeysByPattern(rds.RedisClient, fmt.Sprintf("%s:*", NameSpace))
+	err := audit.Confirm(rds.Keys.Name(), confirmation)
+	if err != nil {
+
+		rds.Audit.Record("DeleteAll", map[string]interface{}{"confirmed": false}, 0, err)
+		return err
+	}
+
+	matchIDs := append(rds.GetMatchIDs(1), rds.GetMatchIDs(3)...)
+
+	deleted, err := utils.CountDeleteKeysByPattern(rds.context(), rds.RedisClient, rds.Keys.NamespacePattern())
+
+	changed := make(map[int64]bool)
+
+	for _, matchID := range matchIDs {
+
+		if !changed[matchID] {
+
+			changed[matchID] = true
+			rds.matchChanged(0, matchID, changelog.Entry{Resync: true})
+		}
+	}
+
+	rds.Audit.Record("DeleteAll", map[string]interface{}{"confirmed": true, "matches": len(changed)}, deleted, err)
+	return err
 
 }
 
+// SetProducerID sets the active producer for a particular match
 func (rds *RedisFeed) SetProducerID(matchID, producerID int64) error {
 
-	redisKey := fmt.Sprintf(constants.ProducerTemplate, matchID)
-	return utils.SetRedisKey(rds.RedisClient, redisKey, fmt.Sprintf("%d", producerID))
+	err := rds.setProducerID(matchID, producerID)
+	if err == nil {
+
+		// reads of the active producer of the match change with the producer
+		rds.matchChanged(0, matchID, changelog.Entry{Resync: true})
+	}
+
+	rds.Audit.Record("SetProducerID", map[string]interface{}{"match_id": matchID, "producer_id": producerID}, 1, err)
+	return err
+
+}
+
+// setProducerID sets the active producer without auditing, used when processing feed messages
+func (rds *RedisFeed) setProducerID(matchID, producerID int64) error {
+
+	redisKey := rds.Keys.Producer(matchID)
+	return utils.SetRedisKey(rds.context(), rds.RedisClient, redisKey, fmt.Sprintf("%d", producerID))
 
 }
 
 // gets the active producer for a particular match
 func (rds *RedisFeed) GetProducerID(matchID int64) (id, status int64) {
 
-	redisKey := fmt.Sprintf(constants.ProducerTemplate, matchID)
-	producer, _ := utils.GetRedisKey(rds.RedisClient, redisKey)
+	redisKey := rds.Keys.Producer(matchID)
+	producer, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisKey)
 	producerID, _ := strconv.ParseInt(producer, 10, 64)
 	return producerID, rds.GetProducerStatus(producerID)
 
 }
 
-func (rds *RedisFeed) keyExist(key string) bool {
-
-	check, err := rds.RedisClient.Exists(key).Result()
-	if err != nil {
+// GetSportID gets the sportID for a particular match
+func (rds *RedisFeed) GetSportID(matchID int64) int64 {
 
-		log.Printf("error saving redisKey %s error %s", key, err.Error())
-		return false
-	}
+	sportsKey := rds.Keys.SportID(matchID)
+	sportIDStr, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, sportsKey)
+	sportID, _ := strconv.ParseInt(sportIDStr, 10, 64)
+	return sportID
 
-	return check > 0
 }
 
-func (rds *RedisFeed) getAllKeysByPattern(keyPattern string) []string {
+func (rds *RedisFeed) keyExist(key string) bool {
 
-	var keys []string
-	iter := rds.RedisClient.Scan(0, keyPattern, 0).Iterator()
-	for iter.Next() {
+	check, _ := utils.RedisKeyExists(rds.context(), rds.RedisClient, key)
+	return check
+}
 
-		keys = append(keys, iter.Val())
-	}
+func (rds *RedisFeed) getAllKeysByPattern(keyPattern string) []string {
 
+	keys, _ := utils.ScanRedisKeys(rds.context(), rds.RedisClient, keyPattern)
 	return keys
 }
 
@@ -929,16 +1019,8 @@ func (rds *RedisFeed) getAllMarketsOrderByPriority(producerID, matchID int64, ma
 
 	DebugMatchID, _ := strconv.ParseInt(os.Getenv("DEBUG_MATCH_ID"), 10, 64)
 
-	// get table name based on producerID
-	tableName := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	if producerID == 1 || producerID == 4 {
-
-		tableName = fmt.Sprintf("%s:%s", NameSpace, constants.LiveSet)
-	}
-
 	// namespace:table:matchID
-	keyName := fmt.Sprintf(constants.KeyTemplate, tableName, matchID)
+	keyName := rds.Keys.Match(producerID, matchID)
 
 	keyExists := rds.keyExist(keyName)
 
@@ -950,7 +1032,7 @@ func (rds *RedisFeed) getAllMarketsOrderByPriority(producerID, matchID int64, ma
 
 	// get all redis keys (market keys) attached to this matchID
 	var keys []string
-	keysData, _ := utils.GetRedisKey(rds.RedisClient, fmt.Sprintf(constants.KeysFieldTemplate, keyName))
+	keysData, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, rds.Keys.MarketKeys(producerID, matchID))
 	if DebugMatchID == matchID {
 
 		log.Printf("got market keys %s ", keysData)
@@ -970,7 +1052,7 @@ func (rds *RedisFeed) getAllMarketsOrderByPriority(producerID, matchID int64, ma
 	// get value for each keys gotten
 	for _, key := range keys {
 
-		matchDataAsString, _ := utils.GetRedisKey(rds.RedisClient, key)
+		matchDataAsString, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, key)
 
 		if DebugMatchID == matchID {
 
@@ -979,7 +1061,7 @@ func (rds *RedisFeed) getAllMarketsOrderByPriority(producerID, matchID int64, ma
 		}
 
 		m := new(models.Market)
-		err = json.Unmarshal([]byte(matchDataAsString), m)
+		err = codec.DecodeMarket([]byte(matchDataAsString), m)
 		if err != nil {
 
 			log.Printf("getAllMarketsOrderByPriority failed to unmarshall %s to JSON %s", matchDataAsString, err.Error())
@@ -1117,80 +1199,26 @@ func (rds *RedisFeed) orderByPriority(markets []models.Market, marketOderList []
 // DeleteMatchOdds Delete all odds and caches for the supplied match
 func (rds *RedisFeed) DeleteMatchOdds(matchID int64) {
 
-	var keysPattern []string
-
-	keysPattern = append(keysPattern, fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet))
-
-	// get table name based on producerID
-	tableNamePrematch := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-	tableNameLive := fmt.Sprintf("%s:%s", NameSpace, constants.PreMatchSet)
-
-	// odds object
-	keyNameLive := fmt.Sprintf(constants.KeyTemplate, tableNameLive, matchID)
-	keyNamePrematch := fmt.Sprintf(constants.KeyTemplate, tableNamePrematch, matchID)
-
-	keysPattern = append(keysPattern, keyNameLive)
-	keysPattern = append(keysPattern, keyNamePrematch)
-
-	// individual markets
-	redisMarketKeyLive := fmt.Sprintf("%s:*", keyNameLive)
-	keysPattern = append(keysPattern, redisMarketKeyLive)
-
-	redisMarketPrematch := fmt.Sprintf("%s:*", keyNamePrematch)
-	keysPattern = append(keysPattern, redisMarketPrematch)
+	// match blobs, market keys lists, producer, sport-id, fixture status and other match keys
+	deleted, _ := utils.DeleteRedisKeys(rds.context(), rds.RedisClient, rds.Keys.MatchKeys(matchID)...)
 
-	// producer
-	producerKey := fmt.Sprintf(constants.ProducerTemplate, matchID)
-	keysPattern = append(keysPattern, producerKey)
+	// individual markets of the live and prematch tables
+	for _, producerID := range []int64{1, 3} {
 
-	// fields key
-	fieldsKeyLive := fmt.Sprintf(constants.KeysFieldTemplate, fmt.Sprintf(constants.KeyTemplate, tableNameLive, matchID))
-	keysPattern = append(keysPattern, fieldsKeyLive)
-
-	fieldsKeyPrematch := fmt.Sprintf(constants.KeysFieldTemplate, fmt.Sprintf(constants.KeyTemplate, tableNamePrematch, matchID))
-	keysPattern = append(keysPattern, fieldsKeyPrematch)
-
-	// default market keys
-	defaultMarketKey := fmt.Sprintf("%s:default-market-id:%d", NameSpace, matchID)
-	keysPattern = append(keysPattern, defaultMarketKey)
-
-	// total market keys
-	totalMarketsKey := fmt.Sprintf("%s:total-markets:%d", NameSpace, matchID)
-	keysPattern = append(keysPattern, totalMarketsKey)
-
-	stasKey := fmt.Sprintf("fixture-stats:%d", matchID)
-	keysPattern = append(keysPattern, stasKey)
-
-	matchPriorityKey := fmt.Sprintf("match-priority:%d", matchID)
-	keysPattern = append(keysPattern, matchPriorityKey)
-
-	// delete match date
-	matchDateKeys := fmt.Sprintf("match-date:%d", matchID)
-	keysPattern = append(keysPattern, matchDateKeys)
-
-	sportsKey := fmt.Sprintf("sport-id:%d", matchID)
-	keysPattern = append(keysPattern, sportsKey)
-
-	for _, key := range keysPattern {
-
-		if strings.Contains(key, "*") {
-
-			utils.DeleteKeysByPattern(rds.RedisClient, key)
-
-		} else {
-
-			utils.DeleteRedisKey(rds.RedisClient, key)
-
-		}
+		count, _ := utils.CountDeleteKeysByPattern(rds.context(), rds.RedisClient, rds.Keys.MatchPattern(producerID, matchID))
+		deleted += count
 	}
 
+	rds.Audit.Record("DeleteMatchOdds", map[string]interface{}{"match_id": matchID}, deleted, nil)
+	rds.matchChanged(0, matchID, changelog.Entry{Resync: true})
+
 }
 
 // GetDefaultMarketID gets the default marketID for a particular sportID
 func (rds *RedisFeed) GetDefaultMarketID(matchID, sportID int64) int64 {
 
-	defaultMarketKey := fmt.Sprintf("%s:default-market-id:%d", NameSpace, matchID)
-	redisValue, _ := utils.GetRedisKey(rds.RedisClient, defaultMarketKey)
+	defaultMarketKey := rds.Keys.DefaultMarket(matchID)
+	redisValue, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, defaultMarketKey)
 	market, _ := strconv.ParseInt(redisValue, 10, 64)
 	if market > 0 {
 
@@ -1207,8 +1235,8 @@ func (rds *RedisFeed) GetDefaultMarketID(matchID, sportID int64) int64 {
 
 func (rds *RedisFeed) GetProducerStatus(producerID int64) int64 {
 
-	redisKey := fmt.Sprintf("producer:status:%d", producerID)
-	dt, _ := utils.GetRedisKey(rds.RedisClient, redisKey)
+	redisKey := rds.Keys.ProducerStatus(producerID)
+	dt, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisKey)
 	producerStatus, _ := strconv.ParseInt(dt, 10, 64)
 	return producerStatus
 
@@ -1219,9 +1247,9 @@ func (rds *RedisFeed) GetFixtureStatus(matchID int64) models.FixtureStatus {
 
 	market := new(models.FixtureStatus)
 
-	redisKey := fmt.Sprintf("fixture-stats:%d", matchID)
+	redisKey := rds.Keys.FixtureStatus(matchID)
 
-	data, _ := utils.GetRedisKey(rds.RedisClient, redisKey)
+	data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisKey)
 	if len(data) == 0 {
 
 		rds.RequestMatchTime(matchID)
@@ -1251,16 +1279,23 @@ func (rds *RedisFeed) GetFixtureStatus(matchID int64) models.FixtureStatus {
 // SetFixtureStatus sets fixture status for the supplied matchID
 func (rds *RedisFeed) SetFixtureStatus(matchID int64, fx models.FixtureStatus) error {
 
-	redisKey := fmt.Sprintf("fixture-stats:%d", matchID)
+	redisKey := rds.Keys.FixtureStatus(matchID)
 
 	js, _ := json.Marshal(fx)
 
-	err := utils.SetRedisKey(rds.RedisClient, redisKey, string(js))
+	err := utils.SetRedisKey(rds.context(), rds.RedisClient, redisKey, string(js))
 	if err != nil {
 
 		log.Printf("error setting redis key %s | %s", redisKey, err.Error())
+
+	} else {
+
+		// the fixture status is shared by all producers of the match
+		rds.matchChanged(0, matchID, changelog.Entry{})
 	}
 
+	rds.Audit.Record("SetFixtureStatus", map[string]interface{}{"match_id": matchID, "fixture_status": fx}, 1, err)
+
 	return err
 
 }
@@ -1276,3 +1311,16 @@ func (rds *RedisFeed) RequestMatchTime(matchID int64) error {
 	return utils.PublishToNats(rds.NatsClient, "match_timeline", map[string]interface{}{"match_id": matchID})
 
 }
+
+// decodeMarkets decodes the markets of a match stored by any codec
+func decodeMarkets(data string, markets *[]models.Market) error {
+
+	decoded, err := codec.DecodeMarkets([]byte(data))
+	if err != nil {
+
+		return err
+	}
+
+	*markets = decoded
+	return nil
+}
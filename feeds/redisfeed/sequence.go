@@ -0,0 +1,119 @@
+package redisfeed
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// GetMatchVersion gets the sequence of the match, the sequence is incremented every time the markets or the fixture
+// status of the match change and is 0 for a match that never changed
+func (rds *RedisFeed) GetMatchVersion(matchID int64) int64 {
+
+	data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, rds.Keys.MatchVersion(matchID))
+	sequence, _ := strconv.ParseInt(data, 10, 64)
+	return sequence
+}
+
+// GetAllMarketsWithSequence gets all markets for a particular matchID and the sequence they were saved with,
+// markets and sequence are read in one round trip
+func (rds *RedisFeed) GetAllMarketsWithSequence(producerID, matchID int64) ([]models.Market, int64) {
+
+	values, err := utils.GetRedisKeys(rds.context(), rds.RedisClient, rds.Keys.Match(producerID, matchID), rds.Keys.MatchVersion(matchID))
+	if err != nil || len(values) != 2 {
+
+		return nil, 0
+	}
+
+	sequence, _ := strconv.ParseInt(values[1], 10, 64)
+
+	if len(values[0]) == 0 {
+
+		rds.RequestOdds(matchID)
+		return nil, sequence
+	}
+
+	var markets []models.Market
+
+	err = decodeMarkets(values[0], &markets)
+	if err != nil {
+
+		log.Printf("GetAllMarketsWithSequence failed to unmarshall %s to JSON %s", values[0], err.Error())
+		return nil, sequence
+	}
+
+	return rds.prepareMarkets(matchID, markets), sequence
+}
+
+// GetMarketsIfChanged gets all markets for a particular matchID when the sequence of the match is not sinceSequence,
+// changed is false and no markets are read when the match did not change since the supplied sequence
+func (rds *RedisFeed) GetMarketsIfChanged(producerID, matchID, sinceSequence int64) (markets []models.Market, sequence int64, changed bool) {
+
+	sequence = rds.GetMatchVersion(matchID)
+	if sequence == sinceSequence {
+
+		return nil, sequence, false
+	}
+
+	markets, sequence = rds.GetAllMarketsWithSequence(producerID, matchID)
+	return markets, sequence, true
+}
+
+// GetChangesSince gets the markets of a particular matchID changed after sinceSequence. FullResync is set and all
+// markets are returned when the change log of the match does not reach back to sinceSequence
+func (rds *RedisFeed) GetChangesSince(producerID, matchID, sinceSequence int64) models.MatchChanges {
+
+	if sinceSequence > 0 && rds.GetMatchVersion(matchID) == sinceSequence {
+
+		return models.MatchChanges{MatchID: matchID, ProducerID: producerID, Sequence: sinceSequence}
+	}
+
+	markets, sequence := rds.GetAllMarketsWithSequence(producerID, matchID)
+	entries := changelog.Read(rds.context(), rds.RedisClient, rds.Keys, matchID)
+
+	return changelog.Changes(entries, matchID, producerID, sinceSequence, sequence, markets)
+}
+
+// matchChanged increments the sequence of the match, logs the change and publishes it to odds_invalidation,
+// used by changes whose writes are not saved together with the sequence e.g deletes
+func (rds *RedisFeed) matchChanged(producerID, matchID int64, change changelog.Entry) {
+
+	rds.publishInvalidation(producerID, matchID, rds.incrementSequence(matchID, change))
+}
+
+// incrementSequence increments the sequence of the match, logs the change and returns the incremented sequence
+func (rds *RedisFeed) incrementSequence(matchID int64, change changelog.Entry) int64 {
+
+	sequence, err := utils.IncrRedisSequence(rds.context(), rds.RedisClient, rds.Keys.MatchVersion(matchID), constants.MatchVersionExpiry)
+	if err != nil {
+
+		log.Printf("error incrementing sequence of match %d | %s", matchID, err.Error())
+		return 0
+	}
+
+	rds.logChange(matchID, sequence, change)
+	return sequence
+}
+
+// logChange adds the change saved with the sequence to the change log of the match
+func (rds *RedisFeed) logChange(matchID, sequence int64, change changelog.Entry) {
+
+	change.Sequence = sequence
+	changelog.Push(rds.context(), rds.RedisClient, rds.Keys, matchID, change)
+}
+
+// saveWithSequence saves the writes of the match and increments its sequence in one transaction
+func (rds *RedisFeed) saveWithSequence(matchID int64, writes map[string]string) (int64, error) {
+
+	return utils.SetRedisKeysWithSequence(rds.context(), rds.RedisClient, writes, rds.Keys.MatchVersion(matchID), constants.MatchVersionExpiry)
+}
+
+// replaceWithSequence deletes keys of the match, saves the writes of the match and increments its sequence in one transaction
+func (rds *RedisFeed) replaceWithSequence(matchID int64, deletes []string, writes map[string]string) (int64, error) {
+
+	return utils.ReplaceRedisKeysWithSequence(rds.context(), rds.RedisClient, deletes, writes, rds.Keys.MatchVersion(matchID), constants.MatchVersionExpiry)
+}
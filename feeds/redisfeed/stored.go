@@ -0,0 +1,140 @@
+package redisfeed
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	goutils "github.com/mudphilo/go-utils"
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// GetStoredFixtureStatus gets the fixture status as stored for the supplied matchID without match time requests,
+// ok is false when no fixture status is stored
+func (rds *RedisFeed) GetStoredFixtureStatus(matchID int64) (fx models.FixtureStatus, ok bool) {
+
+	redisKey := rds.Keys.FixtureStatus(matchID)
+
+	data, _ := utils.GetRedisKey(rds.context(), rds.RedisClient, redisKey)
+	if len(data) == 0 {
+
+		return fx, false
+	}
+
+	err := json.Unmarshal([]byte(data), &fx)
+	if err != nil {
+
+		log.Printf("%s | GetStoredFixtureStatus failed to unmarshall %s to JSON %s", redisKey, data, err.Error())
+		return fx, false
+	}
+
+	return fx, true
+}
+
+// SetStoredMarkets replaces the stored markets of the supplied matchID as they are, markets locked by traders
+// are written as well and nothing is merged with the markets stored before. The markets stored before are deleted
+// in the transaction that writes the markets, a failed write leaves the markets stored before
+func (rds *RedisFeed) SetStoredMarkets(producerID, matchID, sportID int64, markets []models.Market) error {
+
+	params := map[string]interface{}{"producer_id": producerID, "match_id": matchID, "markets": len(markets)}
+
+	// markets blob, market keys and market-keys list of the markets stored before
+	deletes, err := utils.ScanRedisKeys(rds.context(), rds.RedisClient, rds.Keys.MatchPattern(producerID, matchID))
+	if err != nil {
+
+		rds.Audit.Record("SetStoredMarkets", params, 0, err)
+		return err
+	}
+
+	deletes = append(deletes, rds.Keys.Match(producerID, matchID))
+
+	sequence, err := rds.replaceWithSequence(matchID, deletes, rds.newMatchWrites(producerID, matchID, sportID, markets))
+	if err != nil {
+
+		rds.Audit.Record("SetStoredMarkets", params, 0, err)
+		return err
+	}
+
+	rds.logChange(matchID, sequence, changelog.Entry{ProducerID: producerID, Resync: true})
+	rds.publishInvalidation(producerID, matchID, sequence)
+	rds.Audit.Record("SetStoredMarkets", params, int64(len(markets)), nil)
+
+	return nil
+}
+
+// newMatchWrites gets the writes storing the markets of a match that has no stored markets, the blob of all markets,
+// a key per market, the market keys, the default market, the number of active markets and the sport
+func (rds *RedisFeed) newMatchWrites(producerID, matchID, sportID int64, markets []models.Market) map[string]string {
+
+	DebugMatchID, _ := strconv.ParseInt(os.Getenv("DEBUG_MATCH_ID"), 10, 64)
+
+	defaultMarketID := int64(0)
+
+	uniqueTotalMarkets := make(map[int64]int64)
+
+	defaultMarketsList := []string{"1", "186", "219", "340", "251"}
+
+	writes := make(map[string]string)
+
+	var keys []string
+
+	// loop through all the received markets
+	for _, m := range markets {
+
+		cacheValue, _ := rds.Codec.EncodeMarket(m)
+
+		// namespace:table:match-matchID:market-marketID:specifierKey
+		redisMarketKey := rds.Keys.Market(producerID, matchID, m.MarketID, m.Specifier)
+		if matchID == DebugMatchID {
+
+			log.Printf("Saving data to %s ", redisMarketKey)
+
+		}
+
+		// save each market data as redis keys
+		// this will be used on the homepage or when gettings odds via GRPC
+		writes[redisMarketKey] = string(cacheValue)
+
+		// keep a record of all created market keys
+		keys = append(keys, redisMarketKey)
+
+		if defaultMarketID == 0 && len(m.Outcomes) > 0 {
+
+			if goutils.Contains(defaultMarketsList, fmt.Sprintf("%d", m.MarketID)) {
+
+				defaultMarketID = m.MarketID
+
+			}
+
+		}
+
+		if len(m.Outcomes) > 0 && (m.Status == 0 || m.Status == 5) {
+
+			uniqueTotalMarkets[m.MarketID] = 1
+		}
+
+	}
+
+	// save the entire markets into one key, this will be used in get more/detailed/all market endpoint
+	jsonValue, _ := rds.Codec.EncodeMarkets(markets)
+	writes[rds.Keys.Match(producerID, matchID)] = string(jsonValue)
+
+	// save all the market keys for easier retrieval of data later
+	jsonValue, _ = json.Marshal(keys)
+	writes[rds.Keys.MarketKeys(producerID, matchID)] = string(jsonValue)
+
+	if defaultMarketID > 0 {
+
+		writes[rds.Keys.DefaultMarket(matchID)] = fmt.Sprintf("%d", defaultMarketID)
+
+	}
+
+	writes[rds.Keys.TotalMarkets(matchID)] = fmt.Sprintf("%d", len(uniqueTotalMarkets))
+	writes[rds.Keys.SportID(matchID)] = fmt.Sprintf("%d", sportID)
+
+	return writes
+}
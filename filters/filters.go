@@ -0,0 +1,476 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// Action what happens to markets or outcomes matching a rule
+type Action string
+
+// Drop removes matching markets or outcomes from the response
+const Drop Action = "drop"
+
+// Suspend returns matching markets as suspended or matching outcomes as inactive
+const Suspend Action = "suspend"
+
+// Allow whitelists matching markets, once a market is in scope of an allow rule it is dropped unless it matches one
+const Allow Action = "allow"
+
+// Rule market filter, empty or zero criteria match everything.
+// SportID, TournamentID and ProducerID define the scope of the rule, MarketID, SpecifierPattern and OutcomeID select what is filtered
+type Rule struct {
+
+	// ID identifies the rule so that it can be removed at runtime
+	ID string `json:"id"`
+
+	// SportID sport the rule applies to
+	SportID int64 `json:"sport_id"`
+
+	// TournamentID tournament the rule applies to, requires FilteredFeed.MatchInfo to resolve tournaments
+	TournamentID int64 `json:"tournament_id"`
+
+	// ProducerID producer the rule applies to
+	ProducerID int64 `json:"producer_id"`
+
+	// MarketID market the rule applies to
+	MarketID int64 `json:"market_id"`
+
+	// SpecifierPattern regular expression the market specifier must match e.g ^total=
+	SpecifierPattern string `json:"specifier_pattern"`
+
+	// OutcomeID when set only the matching outcome is dropped or suspended, not supported on allow rules
+	OutcomeID string `json:"outcome_id"`
+
+	// Action drop, suspend or allow
+	Action Action `json:"action"`
+
+	specifier *regexp.Regexp
+}
+
+// MatchInfo resolves the sportID and tournamentID of a match
+type MatchInfo func(matchID int64) (sportID, tournamentID int64)
+
+// FilteredFeed drops or suspends markets and outcomes read from the wrapped feed according to the configured rules
+type FilteredFeed struct {
+	feeds.Feed
+
+	// MatchInfo resolves sport and tournament of a match, defaults to the wrapped feed sportID without tournaments
+	MatchInfo MatchInfo
+
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// NewFilteredFeed creates a feed that filters markets read from feed using the supplied rules
+func NewFilteredFeed(feed feeds.Feed, rules ...Rule) (*FilteredFeed, error) {
+
+	f := &FilteredFeed{
+		Feed: feed,
+	}
+
+	f.MatchInfo = func(matchID int64) (int64, int64) {
+
+		return f.Feed.GetSportID(matchID), 0
+	}
+
+	err := f.SetRules(rules)
+	if err != nil {
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// SetRules replaces all the rules, safe to call while the feed is being read
+func (f *FilteredFeed) SetRules(rules []Rule) error {
+
+	compiled := make([]Rule, 0, len(rules))
+
+	for _, r := range rules {
+
+		rule, err := compile(r)
+		if err != nil {
+
+			return err
+		}
+
+		compiled = append(compiled, rule)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rules = compiled
+
+	return nil
+}
+
+// AddRule adds a rule, an existing rule with the same ID is replaced
+func (f *FilteredFeed) AddRule(rule Rule) error {
+
+	rule, err := compile(rule)
+	if err != nil {
+
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, r := range f.rules {
+
+		if len(rule.ID) > 0 && r.ID == rule.ID {
+
+			f.rules[i] = rule
+			return nil
+		}
+	}
+
+	f.rules = append(f.rules, rule)
+
+	return nil
+}
+
+// RemoveRule removes the rule with the supplied ID
+func (f *FilteredFeed) RemoveRule(id string) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var rules []Rule
+
+	for _, r := range f.rules {
+
+		if r.ID != id {
+
+			rules = append(rules, r)
+		}
+	}
+
+	f.rules = rules
+}
+
+// Rules gets the current rules
+func (f *FilteredFeed) Rules() []Rule {
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return append([]Rule(nil), f.rules...)
+}
+
+// ConfigVersion gets a hash of the rules combined with the configuration version of the wrapped feed
+func (f *FilteredFeed) ConfigVersion() string {
+
+	return feeds.ConfigHash(f.Feed, f.Rules())
+}
+
+// GetAllMarkets gets all markets for a particular matchID with filters applied
+func (f *FilteredFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
+
+	return f.FilterMarkets(producerID, matchID, f.Feed.GetAllMarkets(producerID, matchID))
+}
+
+// GetAllMarketsWithSequence gets all markets for a particular matchID with filters applied and the sequence of the match
+func (f *FilteredFeed) GetAllMarketsWithSequence(producerID, matchID int64) ([]models.Market, int64) {
+
+	markets, sequence := f.Feed.GetAllMarketsWithSequence(producerID, matchID)
+	return f.FilterMarkets(producerID, matchID, markets), sequence
+}
+
+// GetMarketsIfChanged gets all markets for a particular matchID with filters applied if the match changed since sinceSequence
+func (f *FilteredFeed) GetMarketsIfChanged(producerID, matchID, sinceSequence int64) ([]models.Market, int64, bool) {
+
+	markets, sequence, changed := f.Feed.GetMarketsIfChanged(producerID, matchID, sinceSequence)
+	if !changed {
+
+		return nil, sequence, false
+	}
+
+	return f.FilterMarkets(producerID, matchID, markets), sequence, true
+}
+
+// GetChangesSince gets the markets of a particular matchID changed after sinceSequence with filters applied
+func (f *FilteredFeed) GetChangesSince(producerID, matchID, sinceSequence int64) models.MatchChanges {
+
+	changes := f.Feed.GetChangesSince(producerID, matchID, sinceSequence)
+	changes.Markets = f.FilterMarkets(producerID, matchID, changes.Markets)
+	return changes
+}
+
+// GetMarket gets market for a particular matchID and marketID with filters applied, returns nil if the market is dropped
+func (f *FilteredFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
+
+	return f.filterMarket(producerID, matchID, f.Feed.GetMarket(producerID, matchID, marketID, specifier))
+}
+
+// GetOdds gets odds for the supplied outcome with filters applied, returns nil if the market or outcome is dropped
+func (f *FilteredFeed) GetOdds(matchID, marketID int64, specifier, outcomeID string) *models.OddsDetails {
+
+	return f.filterOdds(f.Feed.GetOdds(matchID, marketID, specifier, outcomeID))
+}
+
+// GetAllMarketsOrderByList gets all markets order by the supplied list with filters applied
+func (f *FilteredFeed) GetAllMarketsOrderByList(producerID, matchID int64, marketOderList []models.MarketOrderList) []models.Market {
+
+	return f.FilterMarkets(producerID, matchID, f.Feed.GetAllMarketsOrderByList(producerID, matchID, marketOderList))
+}
+
+// GetSpecifiedMarkets gets the specified markets with filters applied
+func (f *FilteredFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList []models.MarketOrderList) []models.Market {
+
+	return f.FilterMarkets(producerID, matchID, f.Feed.GetSpecifiedMarkets(producerID, matchID, marketList))
+}
+
+// GetAllMarketsByLocale gets all translated markets with filters applied
+func (f *FilteredFeed) GetAllMarketsByLocale(producerID, matchID int64, locale string) []models.Market {
+
+	return f.FilterMarkets(producerID, matchID, f.Feed.GetAllMarketsByLocale(producerID, matchID, locale))
+}
+
+// GetMarketByLocale gets translated market with filters applied
+func (f *FilteredFeed) GetMarketByLocale(producerID, matchID, marketID int64, specifier, locale string) *models.Market {
+
+	return f.filterMarket(producerID, matchID, f.Feed.GetMarketByLocale(producerID, matchID, marketID, specifier, locale))
+}
+
+// GetOddsByLocale gets translated odds with filters applied
+func (f *FilteredFeed) GetOddsByLocale(matchID, marketID int64, specifier, outcomeID, locale string) *models.OddsDetails {
+
+	return f.filterOdds(f.Feed.GetOddsByLocale(matchID, marketID, specifier, outcomeID, locale))
+}
+
+// GetAllMarketsOrderByListByLocale gets all translated markets order by the supplied list with filters applied
+func (f *FilteredFeed) GetAllMarketsOrderByListByLocale(producerID, matchID int64, marketOderList []models.MarketOrderList, locale string) []models.Market {
+
+	return f.FilterMarkets(producerID, matchID, f.Feed.GetAllMarketsOrderByListByLocale(producerID, matchID, marketOderList, locale))
+}
+
+// GetSpecifiedMarketsByLocale gets the specified translated markets with filters applied
+func (f *FilteredFeed) GetSpecifiedMarketsByLocale(producerID, matchID int64, marketList []models.MarketOrderList, locale string) []models.Market {
+
+	return f.FilterMarkets(producerID, matchID, f.Feed.GetSpecifiedMarketsByLocale(producerID, matchID, marketList, locale))
+}
+
+// FilterMarkets applies the rules to a copy of the supplied markets of a match
+func (f *FilteredFeed) FilterMarkets(producerID, matchID int64, markets []models.Market) []models.Market {
+
+	if markets == nil {
+
+		return nil
+	}
+
+	sportID, tournamentID := f.MatchInfo(matchID)
+	rules := f.scopedRules(sportID, tournamentID, producerID)
+
+	filtered := make([]models.Market, 0, len(markets))
+
+	for _, m := range markets {
+
+		if market := apply(rules, m); market != nil {
+
+			filtered = append(filtered, *market)
+		}
+	}
+
+	return filtered
+}
+
+func (f *FilteredFeed) filterMarket(producerID, matchID int64, market *models.Market) *models.Market {
+
+	if market == nil {
+
+		return nil
+	}
+
+	sportID, tournamentID := f.MatchInfo(matchID)
+	return apply(f.scopedRules(sportID, tournamentID, producerID), *market)
+}
+
+func (f *FilteredFeed) filterOdds(odds *models.OddsDetails) *models.OddsDetails {
+
+	if odds == nil {
+
+		return nil
+	}
+
+	sportID, tournamentID := f.MatchInfo(odds.MatchID)
+	if sportID == 0 {
+
+		sportID = odds.SportID
+	}
+
+	market := apply(f.scopedRules(sportID, tournamentID, odds.ProducerID), models.Market{
+		MarketID:   odds.MarketID,
+		Specifier:  odds.Specifier,
+		Status:     odds.Status,
+		StatusName: odds.StatusName,
+		Outcomes: []models.Outcome{
+			{
+				OutcomeID: odds.OutcomeID,
+				Active:    odds.Active,
+			},
+		},
+	})
+
+	if market == nil || len(market.Outcomes) == 0 {
+
+		return nil
+	}
+
+	filtered := *odds
+	filtered.Status = market.Status
+	filtered.StatusName = market.StatusName
+	filtered.Active = market.Outcomes[0].Active
+
+	return &filtered
+}
+
+// scopedRules gets the rules whose sport, tournament and producer scope matches the match
+func (f *FilteredFeed) scopedRules(sportID, tournamentID, producerID int64) []Rule {
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var rules []Rule
+
+	for _, r := range f.rules {
+
+		if (r.SportID > 0 && r.SportID != sportID) || (r.TournamentID > 0 && r.TournamentID != tournamentID) || (r.ProducerID > 0 && r.ProducerID != producerID) {
+
+			continue
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules
+}
+
+// apply applies the scoped rules to a copy of the market, returns nil if the market is dropped
+func apply(rules []Rule, market models.Market) *models.Market {
+
+	allowed := true
+
+	for _, r := range rules {
+
+		if r.Action != Allow {
+
+			continue
+		}
+
+		// market is in scope of an allow rule, it has to match one of them
+		allowed = false
+
+		if r.matchesMarket(market) {
+
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+
+		return nil
+	}
+
+	outcomes := market.Outcomes
+
+	for _, r := range rules {
+
+		if r.Action == Allow || !r.matchesMarket(market) {
+
+			continue
+		}
+
+		if len(r.OutcomeID) == 0 {
+
+			if r.Action == Drop {
+
+				return nil
+			}
+
+			market.Status = constants.SuspendedMarketStatus
+			market.StatusName = constants.SuspendedMarketStatusName
+			continue
+		}
+
+		var filtered []models.Outcome
+
+		for _, o := range outcomes {
+
+			if o.OutcomeID == r.OutcomeID {
+
+				if r.Action == Drop {
+
+					continue
+				}
+
+				o.Active = 0
+			}
+
+			filtered = append(filtered, o)
+		}
+
+		outcomes = filtered
+	}
+
+	market.Outcomes = outcomes
+
+	return &market
+}
+
+func (r Rule) matchesMarket(market models.Market) bool {
+
+	if r.MarketID > 0 && r.MarketID != market.MarketID {
+
+		return false
+	}
+
+	if r.specifier != nil && !r.specifier.MatchString(market.Specifier) {
+
+		return false
+	}
+
+	return true
+}
+
+func compile(rule Rule) (Rule, error) {
+
+	switch rule.Action {
+
+	case Drop, Suspend:
+
+	case Allow:
+		if len(rule.OutcomeID) > 0 {
+
+			return rule, fmt.Errorf("rule %s: outcome_id is not supported on allow rules", rule.ID)
+		}
+
+	default:
+		return rule, fmt.Errorf("rule %s: unknown action %s", rule.ID, rule.Action)
+
+	}
+
+	rule.specifier = nil
+
+	if len(rule.SpecifierPattern) > 0 {
+
+		specifier, err := regexp.Compile(rule.SpecifierPattern)
+		if err != nil {
+
+			return rule, fmt.Errorf("rule %s: invalid specifier pattern %s", rule.ID, err.Error())
+		}
+
+		rule.specifier = specifier
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,448 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/oddspb"
+	"github.com/touchvas/odds-sdk/v2/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// MaxBatchOdds maximum number of outcomes of a GetBatchOdds request
+const MaxBatchOdds = 500
+
+// MaxSubscribedMatches maximum number of matches of a SubscribeOdds request
+const MaxSubscribedMatches = 500
+
+// Server grpc OddsService over any feed. Odds subscriptions are driven by the odds_invalidation messages
+// published by OddsChange and BetStop, a subscribed match is read again and sent every time it changes
+type Server struct {
+	oddspb.UnimplementedOddsServiceServer
+
+	// Feed feed the markets are read from
+	Feed feeds.Feed
+
+	// WithContext optional feed bound by the context of a request e.g redisfeed.RedisFeed.WithContext,
+	// requests use Feed without their deadline when not set
+	WithContext func(ctx context.Context) feeds.Feed
+
+	// NatsClient receives odds_invalidation messages, SubscribeOdds is unavailable when not set
+	NatsClient *nats.Conn
+
+	// Health health service, the odds service is SERVING after Register and NOT_SERVING after Shutdown
+	Health *health.Server
+
+	mu           sync.Mutex
+	subscribers  map[int64]map[*subscriber]struct{}
+	subscription *nats.Subscription
+	grpcServer   *grpc.Server
+	shutdown     chan struct{}
+	stopped      bool
+}
+
+// New creates a server over feed, nc receives the invalidations of odds subscriptions
+func New(feed feeds.Feed, nc *nats.Conn) *Server {
+
+	return &Server{
+		Feed:        feed,
+		NatsClient:  nc,
+		Health:      health.NewServer(),
+		subscribers: make(map[int64]map[*subscriber]struct{}),
+		shutdown:    make(chan struct{}),
+	}
+}
+
+// Register registers the odds service and the health service on the grpc server, the grpc server is stopped by Shutdown
+func (s *Server) Register(gs *grpc.Server) {
+
+	s.mu.Lock()
+	s.grpcServer = gs
+	s.mu.Unlock()
+
+	oddspb.RegisterOddsServiceServer(gs, s)
+	healthpb.RegisterHealthServer(gs, s.Health)
+
+	s.Health.SetServingStatus(oddspb.OddsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+// ListenAndServe serves the odds and health services on addr e.g :9090 until Shutdown, returns nil after Shutdown
+func (s *Server) ListenAndServe(addr string, opts ...grpc.ServerOption) error {
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+
+		return err
+	}
+
+	gs := grpc.NewServer(opts...)
+	s.Register(gs)
+
+	s.mu.Lock()
+	stopped := s.stopped
+	s.mu.Unlock()
+
+	if stopped {
+
+		lis.Close()
+		return nil
+	}
+
+	log.Printf("odds grpc server listening on %s", addr)
+
+	return gs.Serve(lis)
+}
+
+// Shutdown reports the services as NOT_SERVING, stops receiving invalidations, ends open subscriptions
+// and gracefully stops the registered grpc server once pending requests are done
+func (s *Server) Shutdown() {
+
+	s.Health.Shutdown()
+
+	s.mu.Lock()
+
+	if !s.stopped {
+
+		s.stopped = true
+		close(s.done())
+	}
+
+	if s.subscription != nil {
+
+		s.subscription.Unsubscribe()
+		s.subscription = nil
+	}
+
+	gs := s.grpcServer
+
+	s.mu.Unlock()
+
+	// open subscriptions return on the closed shutdown channel, GracefulStop waits for them
+	if gs != nil {
+
+		gs.GracefulStop()
+	}
+}
+
+// done gets the channel closed by Shutdown, created here for servers not created with New. Called with mu held
+func (s *Server) done() chan struct{} {
+
+	if s.shutdown == nil {
+
+		s.shutdown = make(chan struct{})
+	}
+
+	return s.shutdown
+}
+
+// GetAllMarkets gets all markets of a match
+func (s *Server) GetAllMarkets(ctx context.Context, req *oddspb.MatchRequest) (*oddspb.MatchMarkets, error) {
+
+	if req.GetMatchId() == 0 {
+
+		return nil, status.Error(codes.InvalidArgument, "match_id is required")
+	}
+
+	feed := s.feed(ctx)
+
+	return s.matchMarkets(feed, req.GetProducerId(), req.GetMatchId(), req.GetLocale()), nil
+}
+
+// GetMarket gets a market of a match
+func (s *Server) GetMarket(ctx context.Context, req *oddspb.MarketRequest) (*oddspb.Market, error) {
+
+	if req.GetMatchId() == 0 || req.GetMarketId() == 0 {
+
+		return nil, status.Error(codes.InvalidArgument, "match_id and market_id are required")
+	}
+
+	feed := s.feed(ctx)
+	producerID := producer(feed, req.GetProducerId(), req.GetMatchId())
+
+	var market *models.Market
+
+	if len(req.GetLocale()) > 0 {
+
+		market = feed.GetMarketByLocale(producerID, req.GetMatchId(), req.GetMarketId(), req.GetSpecifier(), req.GetLocale())
+
+	} else {
+
+		market = feed.GetMarket(producerID, req.GetMatchId(), req.GetMarketId(), req.GetSpecifier())
+	}
+
+	if market == nil {
+
+		return nil, status.Errorf(codes.NotFound, "market %d:%s of match %d not found", req.GetMarketId(), req.GetSpecifier(), req.GetMatchId())
+	}
+
+	return oddspb.FromMarket(*market), nil
+}
+
+// GetOdds gets the odds of an outcome
+func (s *Server) GetOdds(ctx context.Context, req *oddspb.OddsRequest) (*oddspb.OddsDetails, error) {
+
+	if req.GetMatchId() == 0 || req.GetMarketId() == 0 || len(req.GetOutcomeId()) == 0 {
+
+		return nil, status.Error(codes.InvalidArgument, "match_id, market_id and outcome_id are required")
+	}
+
+	odds := getOdds(s.feed(ctx), req)
+	if odds == nil {
+
+		return nil, status.Errorf(codes.NotFound, "outcome %s of market %d:%s of match %d not found", req.GetOutcomeId(), req.GetMarketId(), req.GetSpecifier(), req.GetMatchId())
+	}
+
+	return oddspb.FromOddsDetails(odds), nil
+}
+
+// GetBatchOdds gets the odds of several outcomes
+func (s *Server) GetBatchOdds(ctx context.Context, req *oddspb.BatchOddsRequest) (*oddspb.BatchOddsReply, error) {
+
+	if len(req.GetOdds()) > MaxBatchOdds {
+
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d outcomes per request", MaxBatchOdds)
+	}
+
+	feed := s.feed(ctx)
+
+	reply := &oddspb.BatchOddsReply{}
+
+	for _, r := range req.GetOdds() {
+
+		if ctx.Err() != nil {
+
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		reply.Results = append(reply.Results, &oddspb.OddsResult{
+			Request: r,
+			Odds:    oddspb.FromOddsDetails(getOdds(feed, r)),
+		})
+	}
+
+	return reply, nil
+}
+
+// GetFixtureStatus gets the status and score of a match
+func (s *Server) GetFixtureStatus(ctx context.Context, req *oddspb.FixtureStatusRequest) (*oddspb.FixtureStatus, error) {
+
+	if req.GetMatchId() == 0 {
+
+		return nil, status.Error(codes.InvalidArgument, "match_id is required")
+	}
+
+	return oddspb.FromFixtureStatus(s.feed(ctx).GetFixtureStatus(req.GetMatchId())), nil
+}
+
+// SubscribeOdds sends all markets of the matches and then all markets of a match every time it changes.
+// Changes received while a client is slow are collapsed, the client gets the latest markets of each changed match
+func (s *Server) SubscribeOdds(req *oddspb.SubscribeRequest, stream oddspb.OddsService_SubscribeOddsServer) error {
+
+	if len(req.GetMatchIds()) == 0 || len(req.GetMatchIds()) > MaxSubscribedMatches {
+
+		return status.Errorf(codes.InvalidArgument, "between 1 and %d match_ids are required", MaxSubscribedMatches)
+	}
+
+	sub := newSubscriber()
+
+	shutdown, err := s.subscribe(sub, req.GetMatchIds())
+	if err != nil {
+
+		return err
+	}
+
+	defer s.unsubscribe(sub, req.GetMatchIds())
+
+	ctx := stream.Context()
+	feed := s.feed(ctx)
+
+	for _, matchID := range req.GetMatchIds() {
+
+		err = stream.Send(s.matchMarkets(feed, req.GetProducerId(), matchID, req.GetLocale()))
+		if err != nil {
+
+			return err
+		}
+	}
+
+	for {
+
+		select {
+
+		case <-ctx.Done():
+			return nil
+
+		case <-shutdown:
+			return status.Error(codes.Unavailable, "server is shutting down")
+
+		case <-sub.signal:
+			for _, matchID := range sub.take() {
+
+				err = stream.Send(s.matchMarkets(feed, req.GetProducerId(), matchID, req.GetLocale()))
+				if err != nil {
+
+					return err
+				}
+			}
+
+		}
+	}
+}
+
+// feed gets the feed bound by the context when WithContext is set
+func (s *Server) feed(ctx context.Context) feeds.Feed {
+
+	if s.WithContext != nil {
+
+		return s.WithContext(ctx)
+	}
+
+	return s.Feed
+}
+
+func (s *Server) matchMarkets(feed feeds.Feed, producerID, matchID int64, locale string) *oddspb.MatchMarkets {
+
+	producerID = producer(feed, producerID, matchID)
+
+	var markets []models.Market
+	var sequence int64
+
+	if len(locale) > 0 {
+
+		// the sequence is read first so the markets are never older than the sequence
+		sequence = feed.GetMatchVersion(matchID)
+		markets = feed.GetAllMarketsByLocale(producerID, matchID, locale)
+
+	} else {
+
+		markets, sequence = feed.GetAllMarketsWithSequence(producerID, matchID)
+	}
+
+	return &oddspb.MatchMarkets{
+		MatchId:    matchID,
+		ProducerId: producerID,
+		Markets:    oddspb.FromMarkets(markets),
+		Timestamp:  time.Now().UnixMilli(),
+		Sequence:   sequence,
+	}
+}
+
+// subscribe adds the subscriber to the matches and gets the channel closed on Shutdown,
+// the invalidations subscription is created with the first subscriber
+func (s *Server) subscribe(sub *subscriber, matchIDs []int64) (<-chan struct{}, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+
+	if s.subscription == nil {
+
+		if s.NatsClient == nil {
+
+			return nil, status.Error(codes.Unavailable, "odds subscriptions are not available")
+		}
+
+		subscription, err := s.NatsClient.Subscribe(utils.NatsSubject(constants.OddsInvalidationTopic), s.invalidated)
+		if err != nil {
+
+			log.Printf("error subscribing to odds invalidation %s", err.Error())
+			return nil, status.Error(codes.Unavailable, "odds subscriptions are not available")
+		}
+
+		s.subscription = subscription
+	}
+
+	for _, matchID := range matchIDs {
+
+		subscribers, ok := s.subscribers[matchID]
+		if !ok {
+
+			subscribers = make(map[*subscriber]struct{})
+			s.subscribers[matchID] = subscribers
+		}
+
+		subscribers[sub] = struct{}{}
+	}
+
+	return s.done(), nil
+}
+
+func (s *Server) unsubscribe(sub *subscriber, matchIDs []int64) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, matchID := range matchIDs {
+
+		delete(s.subscribers[matchID], sub)
+
+		if len(s.subscribers[matchID]) == 0 {
+
+			delete(s.subscribers, matchID)
+		}
+	}
+}
+
+// invalidated notifies the subscribers of the changed match
+func (s *Server) invalidated(msg *nats.Msg) {
+
+	var invalidation models.OddsInvalidation
+
+	err := json.Unmarshal(msg.Data, &invalidation)
+	if err != nil {
+
+		log.Printf("error decoding odds invalidation %s | %s", string(msg.Data), err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers[invalidation.MatchID] {
+
+		sub.notify(invalidation.MatchID)
+	}
+}
+
+// producer gets the active producer of the match when producerID is 0
+func producer(feed feeds.Feed, producerID, matchID int64) int64 {
+
+	if producerID > 0 {
+
+		return producerID
+	}
+
+	producerID, _ = feed.GetProducerID(matchID)
+	if producerID == 0 {
+
+		producerID = 3
+	}
+
+	return producerID
+}
+
+func getOdds(feed feeds.Feed, req *oddspb.OddsRequest) *models.OddsDetails {
+
+	if len(req.GetLocale()) > 0 {
+
+		return feed.GetOddsByLocale(req.GetMatchId(), req.GetMarketId(), req.GetSpecifier(), req.GetOutcomeId(), req.GetLocale())
+	}
+
+	return feed.GetOdds(req.GetMatchId(), req.GetMarketId(), req.GetSpecifier(), req.GetOutcomeId())
+}
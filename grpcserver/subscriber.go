@@ -0,0 +1,55 @@
+package grpcserver
+
+import "sync"
+
+// subscriber changed matches of an odds subscription, matches changed again before they are sent are sent once
+type subscriber struct {
+	mu      sync.Mutex
+	changed map[int64]struct{}
+	order   []int64
+
+	// signal has a value when there are changed matches
+	signal chan struct{}
+}
+
+func newSubscriber() *subscriber {
+
+	return &subscriber{
+		changed: make(map[int64]struct{}),
+		signal:  make(chan struct{}, 1),
+	}
+}
+
+// notify marks the match as changed, it never blocks the invalidations subscription
+func (s *subscriber) notify(matchID int64) {
+
+	s.mu.Lock()
+
+	if _, ok := s.changed[matchID]; !ok {
+
+		s.changed[matchID] = struct{}{}
+		s.order = append(s.order, matchID)
+	}
+
+	s.mu.Unlock()
+
+	select {
+
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// take gets and clears the changed matches in the order they changed
+func (s *subscriber) take() []int64 {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order := s.order
+
+	s.changed = make(map[int64]struct{})
+	s.order = nil
+
+	return order
+}
@@ -0,0 +1,399 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// CacheControl default Cache-Control of the responses, clients revalidate with If-None-Match
+const CacheControl = "no-cache"
+
+// Handler read API of a feed over net/http
+//
+//	GET /matches/{id}/markets?producer_id=&locale=
+//	GET /matches/{id}/markets/{market}/{specifier}?producer_id=&locale=
+//	GET /odds?match_id=&market_id=&specifier=&outcome_id=&locale=
+//	GET /fixtures/{id}
+//	GET /matches/{id}/changes?since=&producer_id=
+//
+// Market and odds responses carry the version of the match and the configuration version of the feed (feeds.Configured)
+// as ETag, a request whose If-None-Match has the current versions gets 304 Not Modified without the markets being read.
+// The version is read before the markets so an ETag is never newer than the markets it is sent with. Fixture responses carry a hash of the fixture status as ETag.
+// Changes since a sequence are not cached, the response depends on the since parameter.
+// A market without a specifier is served at /matches/{id}/markets/{market} or with the no-specifier specifier
+type Handler struct {
+
+	// Feed feed the markets are read from
+	Feed feeds.Feed
+
+	// WithContext optional feed bound by the context of a request e.g redisfeed.RedisFeed.WithContext
+	WithContext func(ctx context.Context) feeds.Feed
+
+	// CacheControl Cache-Control of the responses, CacheControl when empty
+	CacheControl string
+
+	mux *http.ServeMux
+}
+
+// New creates a handler over feed
+func New(feed feeds.Feed) *Handler {
+
+	h := &Handler{
+		Feed: feed,
+		mux:  http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("GET /matches/{id}/markets", h.getAllMarkets)
+	h.mux.HandleFunc("GET /matches/{id}/markets/{market}", h.getMarket)
+	h.mux.HandleFunc("GET /matches/{id}/markets/{market}/{specifier}", h.getMarket)
+	h.mux.HandleFunc("GET /odds", h.getOdds)
+	h.mux.HandleFunc("GET /fixtures/{id}", h.getFixtureStatus)
+	h.mux.HandleFunc("GET /matches/{id}/changes", h.getChanges)
+
+	return h
+}
+
+// ServeHTTP serves the read API, mount it with http.StripPrefix to serve it under a path
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) getAllMarkets(w http.ResponseWriter, r *http.Request) {
+
+	matchID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid match id")
+		return
+	}
+
+	feed := h.feed(r)
+
+	etag := versionTag(matchID, feed.GetMatchVersion(matchID), feeds.ConfigVersion(feed))
+	if h.notModified(w, r, etag) {
+
+		return
+	}
+
+	producerID := producer(feed, r, matchID)
+	locale := r.URL.Query().Get("locale")
+
+	var markets []models.Market
+
+	if len(locale) > 0 {
+
+		markets = feed.GetAllMarketsByLocale(producerID, matchID, locale)
+
+	} else {
+
+		markets = feed.GetAllMarkets(producerID, matchID)
+	}
+
+	if markets == nil {
+
+		markets = []models.Market{}
+	}
+
+	h.writeJSON(w, etag, markets)
+}
+
+func (h *Handler) getMarket(w http.ResponseWriter, r *http.Request) {
+
+	matchID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid match id")
+		return
+	}
+
+	marketID, err := strconv.ParseInt(r.PathValue("market"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid market id")
+		return
+	}
+
+	specifier := r.PathValue("specifier")
+	if specifier == constants.EmptySpecifier {
+
+		specifier = ""
+	}
+
+	feed := h.feed(r)
+
+	etag := versionTag(matchID, feed.GetMatchVersion(matchID), feeds.ConfigVersion(feed))
+	if h.notModified(w, r, etag) {
+
+		return
+	}
+
+	producerID := producer(feed, r, matchID)
+	locale := r.URL.Query().Get("locale")
+
+	var market *models.Market
+
+	if len(locale) > 0 {
+
+		market = feed.GetMarketByLocale(producerID, matchID, marketID, specifier, locale)
+
+	} else {
+
+		market = feed.GetMarket(producerID, matchID, marketID, specifier)
+	}
+
+	if market == nil {
+
+		writeError(w, http.StatusNotFound, "market not found")
+		return
+	}
+
+	h.writeJSON(w, etag, market)
+}
+
+func (h *Handler) getOdds(w http.ResponseWriter, r *http.Request) {
+
+	query := r.URL.Query()
+
+	matchID, err := strconv.ParseInt(query.Get("match_id"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid match_id")
+		return
+	}
+
+	marketID, err := strconv.ParseInt(query.Get("market_id"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid market_id")
+		return
+	}
+
+	outcomeID := query.Get("outcome_id")
+	if len(outcomeID) == 0 {
+
+		writeError(w, http.StatusBadRequest, "outcome_id is required")
+		return
+	}
+
+	specifier := query.Get("specifier")
+	locale := query.Get("locale")
+
+	feed := h.feed(r)
+
+	etag := versionTag(matchID, feed.GetMatchVersion(matchID), feeds.ConfigVersion(feed))
+	if h.notModified(w, r, etag) {
+
+		return
+	}
+
+	var odds *models.OddsDetails
+
+	if len(locale) > 0 {
+
+		odds = feed.GetOddsByLocale(matchID, marketID, specifier, outcomeID, locale)
+
+	} else {
+
+		odds = feed.GetOdds(matchID, marketID, specifier, outcomeID)
+	}
+
+	if odds == nil {
+
+		writeError(w, http.StatusNotFound, "outcome not found")
+		return
+	}
+
+	h.writeJSON(w, etag, odds)
+}
+
+func (h *Handler) getFixtureStatus(w http.ResponseWriter, r *http.Request) {
+
+	matchID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid match id")
+		return
+	}
+
+	js, err := json.Marshal(h.feed(r).GetFixtureStatus(matchID))
+	if err != nil {
+
+		writeError(w, http.StatusInternalServerError, "error encoding fixture status")
+		return
+	}
+
+	sum := sha1.Sum(js)
+	etag := fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:8]))
+
+	if h.notModified(w, r, etag) {
+
+		return
+	}
+
+	h.write(w, etag, js)
+}
+
+func (h *Handler) getChanges(w http.ResponseWriter, r *http.Request) {
+
+	matchID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+
+		writeError(w, http.StatusBadRequest, "invalid match id")
+		return
+	}
+
+	since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+
+	feed := h.feed(r)
+
+	changes := feed.GetChangesSince(producer(feed, r, matchID), matchID, since)
+	if changes.Markets == nil {
+
+		changes.Markets = []models.Market{}
+	}
+
+	js, err := json.Marshal(changes)
+	if err != nil {
+
+		writeError(w, http.StatusInternalServerError, "error encoding response")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(js)
+}
+
+// feed gets the feed bound by the context of the request when WithContext is set
+func (h *Handler) feed(r *http.Request) feeds.Feed {
+
+	if h.WithContext != nil {
+
+		return h.WithContext(r.Context())
+	}
+
+	return h.Feed
+}
+
+// notModified writes 304 Not Modified when If-None-Match has the etag
+func (h *Handler) notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+
+	if !matches(r.Header.Get("If-None-Match"), etag) {
+
+		return false
+	}
+
+	h.headers(w, etag)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, etag string, v interface{}) {
+
+	js, err := json.Marshal(v)
+	if err != nil {
+
+		log.Printf("error encoding response %s", err.Error())
+		writeError(w, http.StatusInternalServerError, "error encoding response")
+		return
+	}
+
+	h.write(w, etag, js)
+}
+
+func (h *Handler) write(w http.ResponseWriter, etag string, js []byte) {
+
+	h.headers(w, etag)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(js)
+}
+
+func (h *Handler) headers(w http.ResponseWriter, etag string) {
+
+	cacheControl := h.CacheControl
+	if len(cacheControl) == 0 {
+
+		cacheControl = CacheControl
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+
+	js, _ := json.Marshal(map[string]string{"error": message})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(js)
+}
+
+// versionTag gets the weak ETag of the version of the match and the configuration version of the feed, weak as responses
+// of a version may be compressed or formatted differently by intermediaries
+func versionTag(matchID, version int64, config string) string {
+
+	if len(config) == 0 {
+
+		return fmt.Sprintf(`W/"%d-%d"`, matchID, version)
+	}
+
+	sum := sha1.Sum([]byte(config))
+	return fmt.Sprintf(`W/"%d-%d-%s"`, matchID, version, hex.EncodeToString(sum[:4]))
+}
+
+// matches checks if the If-None-Match header has the etag, tags are compared weakly
+func matches(ifNoneMatch, etag string) bool {
+
+	if len(ifNoneMatch) == 0 {
+
+		return false
+	}
+
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+
+		return true
+	}
+
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(etag, "W/") {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// producer gets producer_id of the request, the active producer of the match when not set
+func producer(feed feeds.Feed, r *http.Request, matchID int64) int64 {
+
+	producerID, _ := strconv.ParseInt(r.URL.Query().Get("producer_id"), 10, 64)
+	if producerID > 0 {
+
+		return producerID
+	}
+
+	producerID, _ = feed.GetProducerID(matchID)
+	if producerID == 0 {
+
+		producerID = 3
+	}
+
+	return producerID
+}
@@ -0,0 +1,324 @@
+package keyspace
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/constants"
+)
+
+// Keyspace builds the redis keys of a feed namespace, keys of tenants sharing a redis database are separated by the tenant Prefix.
+// Keys are built without FEEDS_REDIS_KEY_PREFIX, the prefix is applied by Key when a key is sent to redis
+// and removed by Strip from keys returned by SCAN, so built keys, scanned keys and stored lists of keys always agree
+type Keyspace struct {
+
+	// Prefix tenant prefix of every key built by the keyspace, empty for single tenant deployments
+	Prefix string
+
+	// Namespace feed namespace of the match and market keys
+	Namespace string
+
+	// HashTags wraps the matchID of every match key in a {match:ID} hash tag so all keys of a match are in one slot of a redis cluster
+	HashTags bool
+}
+
+// New creates the keyspace of the supplied namespace
+func New(namespace string) Keyspace {
+
+	return Keyspace{
+		Namespace: namespace,
+	}
+}
+
+// WithPrefix gets a copy of the keyspace whose keys are prefixed with the supplied tenant prefix
+func (k Keyspace) WithPrefix(prefix string) Keyspace {
+
+	k.Prefix = prefix
+	return k
+}
+
+// WithHashTags gets a copy of the keyspace whose match keys are hash tagged per match, required on a redis cluster.
+// Hash tagged keys are not the keys of an untagged keyspace, export and import a snapshot to move the data between layouts
+func (k Keyspace) WithHashTags(hashTags bool) Keyspace {
+
+	k.HashTags = hashTags
+	return k
+}
+
+// Name gets the namespace including the tenant prefix, used to identify the keyspace e.g in audit entries
+func (k Keyspace) Name() string {
+
+	return k.key(k.Namespace)
+}
+
+// FromEnv creates the keyspace of the ODDS_FEED_NAMESPACE namespace
+func FromEnv() Keyspace {
+
+	return New(os.Getenv("ODDS_FEED_NAMESPACE"))
+}
+
+// IsLive checks if markets of the producer are stored in the live table
+func IsLive(producerID int64) bool {
+
+	return producerID == 1 || producerID == 4
+}
+
+// Table gets the table of the producer, namespace:live_feeds for live producers else namespace:prematch_feeds
+func (k Keyspace) Table(producerID int64) string {
+
+	if IsLive(producerID) {
+
+		return k.key(fmt.Sprintf("%s:%s", k.Namespace, constants.LiveSet))
+	}
+
+	return k.key(fmt.Sprintf("%s:%s", k.Namespace, constants.PreMatchSet))
+}
+
+// Match gets the key of the blob with all markets of the match, namespace:table:matchID
+func (k Keyspace) Match(producerID, matchID int64) string {
+
+	return fmt.Sprintf("%s:%s", k.Table(producerID), k.match(matchID))
+}
+
+// MarketKeys gets the key of the list of market keys of the match, namespace:table:matchID:market-keys
+func (k Keyspace) MarketKeys(producerID, matchID int64) string {
+
+	return fmt.Sprintf(constants.KeysFieldTemplate, k.Match(producerID, matchID))
+}
+
+// Market gets the key of a market of the match, namespace:table:matchID:market-marketID:specifier
+// an empty specifier is stored as no-specifier to avoid an empty part in the key
+func (k Keyspace) Market(producerID, matchID, marketID int64, specifier string) string {
+
+	if len(specifier) == 0 {
+
+		specifier = constants.EmptySpecifier
+	}
+
+	return fmt.Sprintf("%s:market-%d:%s", k.Match(producerID, matchID), marketID, specifier)
+}
+
+// MatchPattern gets the pattern matching the market keys and market keys list of the match
+func (k Keyspace) MatchPattern(producerID, matchID int64) string {
+
+	return fmt.Sprintf("%s:*", k.Match(producerID, matchID))
+}
+
+// TablePattern gets the pattern matching all keys of the producer table
+func (k Keyspace) TablePattern(producerID int64) string {
+
+	return fmt.Sprintf("%s:*", k.Table(producerID))
+}
+
+// NamespacePattern gets the pattern matching all keys of the namespace
+func (k Keyspace) NamespacePattern() string {
+
+	return fmt.Sprintf("%s:*", k.Name())
+}
+
+// ParseMatch gets the matchID and the remainder of a key of the producer table,
+// the remainder is empty for the match blob. ok is false if the key is not a key of the table
+func (k Keyspace) ParseMatch(producerID int64, key string) (matchID int64, remainder string, ok bool) {
+
+	table := k.Table(producerID) + ":"
+
+	if !strings.HasPrefix(key, table) {
+
+		return 0, "", false
+	}
+
+	rest := strings.TrimPrefix(key, table)
+
+	if k.HashTags {
+
+		// {match:ID} contains a colon, take the matchID from the tag
+		end := strings.Index(rest, "}")
+		if !strings.HasPrefix(rest, hashTagStart) || end < 0 {
+
+			return 0, "", false
+		}
+
+		rest = rest[len(hashTagStart):end] + rest[end+1:]
+	}
+
+	parts := strings.SplitN(rest, ":", 2)
+
+	matchID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+
+		return 0, "", false
+	}
+
+	if len(parts) == 2 {
+
+		remainder = parts[1]
+	}
+
+	return matchID, remainder, true
+}
+
+// DefaultMarket gets the key of the default marketID of the match
+func (k Keyspace) DefaultMarket(matchID int64) string {
+
+	return k.key(fmt.Sprintf("%s:default-market-id:%s", k.Namespace, k.match(matchID)))
+}
+
+// TotalMarkets gets the key of the number of active markets of the match
+func (k Keyspace) TotalMarkets(matchID int64) string {
+
+	return k.key(fmt.Sprintf("%s:total-markets:%s", k.Namespace, k.match(matchID)))
+}
+
+// Producer gets the key of the active producer of the match
+func (k Keyspace) Producer(matchID int64) string {
+
+	return k.key(fmt.Sprintf("match-active-producer:%s", k.match(matchID)))
+}
+
+// ProducerStatus gets the key of the status of the producer, producer status is shared by all tenants and is not prefixed
+func (k Keyspace) ProducerStatus(producerID int64) string {
+
+	return fmt.Sprintf("producer:status:%d", producerID)
+}
+
+// SportID gets the key of the sportID of the match
+func (k Keyspace) SportID(matchID int64) string {
+
+	return k.key(fmt.Sprintf("sport-id:%s", k.match(matchID)))
+}
+
+// FixtureStatus gets the key of the fixture status of the match
+func (k Keyspace) FixtureStatus(matchID int64) string {
+
+	return k.key(fmt.Sprintf("fixture-stats:%s", k.match(matchID)))
+}
+
+// MatchVersion gets the key of the version (change sequence) of the match. The key is not a key of the namespace
+// and is kept when the match is deleted so sequences of a match never repeat
+func (k Keyspace) MatchVersion(matchID int64) string {
+
+	return k.key(fmt.Sprintf("match-version:%s", k.match(matchID)))
+}
+
+// MatchChanges gets the key of the change log of the match, kept when the match is deleted as MatchVersion
+func (k Keyspace) MatchChanges(matchID int64) string {
+
+	return k.key(fmt.Sprintf("match-changes:%s", k.match(matchID)))
+}
+
+// MatchPriority gets the key of the priority of the match
+func (k Keyspace) MatchPriority(matchID int64) string {
+
+	return k.key(fmt.Sprintf("match-priority:%s", k.match(matchID)))
+}
+
+// MatchDate gets the key of the date of the match
+func (k Keyspace) MatchDate(matchID int64) string {
+
+	return k.key(fmt.Sprintf("match-date:%s", k.match(matchID)))
+}
+
+// MatchKeys gets all keys of the match except the market keys, which are matched by MatchPattern
+func (k Keyspace) MatchKeys(matchID int64) []string {
+
+	var keys []string
+
+	for _, producerID := range []int64{1, 3} {
+
+		keys = append(keys, k.Match(producerID, matchID), k.MarketKeys(producerID, matchID))
+	}
+
+	return append(keys,
+		k.Producer(matchID),
+		k.DefaultMarket(matchID),
+		k.TotalMarkets(matchID),
+		k.FixtureStatus(matchID),
+		k.MatchPriority(matchID),
+		k.MatchDate(matchID),
+		k.SportID(matchID),
+	)
+}
+
+// MarketTranslation gets the key of the translated name of the market
+func (k Keyspace) MarketTranslation(marketID int64, locale string) string {
+
+	return k.key(fmt.Sprintf(constants.MarketTranslationTemplate, marketID, locale))
+}
+
+// OutcomeTranslation gets the key of the translated name of the outcome
+func (k Keyspace) OutcomeTranslation(marketID int64, outcomeID, locale string) string {
+
+	return k.key(fmt.Sprintf(constants.OutcomeTranslationTemplate, marketID, outcomeID, locale))
+}
+
+// TranslationsVersion gets the key of the version of the translations
+func (k Keyspace) TranslationsVersion() string {
+
+	return k.key(constants.TranslationsVersionKey)
+}
+
+// Overrides gets the key of the trader overrides of the match
+func (k Keyspace) Overrides(matchID int64) string {
+
+	return k.key(fmt.Sprintf(constants.OverridesTemplate, matchID))
+}
+
+// OverridesAudit gets the key of the trader actions of the match
+func (k Keyspace) OverridesAudit(matchID int64) string {
+
+	return k.key(fmt.Sprintf(constants.OverridesAuditTemplate, matchID))
+}
+
+const hashTagStart = "{match:"
+
+// match gets the matchID part of the keys of the match, {match:ID} when hash tags are enabled
+func (k Keyspace) match(matchID int64) string {
+
+	if k.HashTags {
+
+		return fmt.Sprintf("%s%d}", hashTagStart, matchID)
+	}
+
+	return strconv.FormatInt(matchID, 10)
+}
+
+// key applies the tenant prefix to the key
+func (k Keyspace) key(key string) string {
+
+	if len(k.Prefix) > 0 {
+
+		return fmt.Sprintf("%s:%s", k.Prefix, key)
+	}
+
+	return key
+}
+
+// Prefix gets FEEDS_REDIS_KEY_PREFIX
+func Prefix() string {
+
+	return os.Getenv("FEEDS_REDIS_KEY_PREFIX")
+}
+
+// Key gets the redis key of the supplied key with FEEDS_REDIS_KEY_PREFIX applied
+func Key(key string) string {
+
+	if prefix := Prefix(); len(prefix) > 0 {
+
+		return fmt.Sprintf("%s:%s", prefix, key)
+	}
+
+	return key
+}
+
+// Strip removes FEEDS_REDIS_KEY_PREFIX from a key returned by redis e.g from SCAN
+func Strip(key string) string {
+
+	if prefix := Prefix(); len(prefix) > 0 {
+
+		return strings.TrimPrefix(key, prefix+":")
+	}
+
+	return key
+}
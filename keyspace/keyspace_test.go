@@ -0,0 +1,201 @@
+package keyspace
+
+import (
+	"path"
+	"testing"
+)
+
+// layouts keyspaces covered by the tests, with and without FEEDS_REDIS_KEY_PREFIX, tenant prefix and hash tags
+var layouts = []struct {
+	name      string
+	keyPrefix string
+	keyspace  Keyspace
+	match     string
+}{
+	{name: "plain", keyspace: New("feeds"), match: "feeds:live_feeds:123"},
+	{name: "key prefix", keyPrefix: "staging", keyspace: New("feeds"), match: "feeds:live_feeds:123"},
+	{name: "tenant", keyspace: New("feeds").WithPrefix("tenant-a"), match: "tenant-a:feeds:live_feeds:123"},
+	{name: "tenant and key prefix", keyPrefix: "staging", keyspace: New("feeds").WithPrefix("tenant-a"), match: "tenant-a:feeds:live_feeds:123"},
+	{name: "hash tags", keyspace: New("feeds").WithHashTags(true), match: "feeds:live_feeds:{match:123}"},
+	{name: "hash tags tenant and key prefix", keyPrefix: "staging", keyspace: New("feeds").WithPrefix("tenant-a").WithHashTags(true), match: "tenant-a:feeds:live_feeds:{match:123}"},
+}
+
+// scan matches a redis key against a SCAN pattern, the keys have no / so path.Match globbing is the redis globbing
+func scan(t *testing.T, pattern, key string) bool {
+
+	t.Helper()
+
+	ok, err := path.Match(pattern, key)
+	if err != nil {
+
+		t.Fatalf("invalid pattern %s | %s", pattern, err.Error())
+	}
+
+	return ok
+}
+
+func TestMatchKey(t *testing.T) {
+
+	for _, l := range layouts {
+
+		t.Run(l.name, func(t *testing.T) {
+
+			t.Setenv("FEEDS_REDIS_KEY_PREFIX", l.keyPrefix)
+
+			if got := l.keyspace.Match(1, 123); got != l.match {
+
+				t.Fatalf("Match(1, 123) = %s, want %s", got, l.match)
+			}
+
+			want := l.match
+			if len(l.keyPrefix) > 0 {
+
+				want = l.keyPrefix + ":" + l.match
+			}
+
+			if got := Key(l.keyspace.Match(1, 123)); got != want {
+
+				t.Fatalf("Key(Match(1, 123)) = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+
+	for _, l := range layouts {
+
+		t.Run(l.name, func(t *testing.T) {
+
+			t.Setenv("FEEDS_REDIS_KEY_PREFIX", l.keyPrefix)
+
+			ks := l.keyspace
+
+			for _, producerID := range []int64{1, 3} {
+
+				keys := []struct {
+					key       string
+					remainder string
+				}{
+					{key: ks.Match(producerID, 123), remainder: ""},
+					{key: ks.MarketKeys(producerID, 123), remainder: "market-keys"},
+					{key: ks.Market(producerID, 123, 18, "total=2.5"), remainder: "market-18:total=2.5"},
+					{key: ks.Market(producerID, 123, 1, ""), remainder: "market-1:no-specifier"},
+				}
+
+				for _, k := range keys {
+
+					// key as stored in redis and as returned by SCAN
+					redisKey := Key(k.key)
+
+					if !scan(t, Key(ks.TablePattern(producerID)), redisKey) {
+
+						t.Errorf("%s does not match table pattern %s", redisKey, Key(ks.TablePattern(producerID)))
+					}
+
+					if !scan(t, Key(ks.NamespacePattern()), redisKey) {
+
+						t.Errorf("%s does not match namespace pattern %s", redisKey, Key(ks.NamespacePattern()))
+					}
+
+					// the blob is scanned by its own key, everything else by the match pattern
+					if len(k.remainder) > 0 && !scan(t, Key(ks.MatchPattern(producerID, 123)), redisKey) {
+
+						t.Errorf("%s does not match match pattern %s", redisKey, Key(ks.MatchPattern(producerID, 123)))
+					}
+
+					stripped := Strip(redisKey)
+					if stripped != k.key {
+
+						t.Fatalf("Strip(%s) = %s, want %s", redisKey, stripped, k.key)
+					}
+
+					matchID, remainder, ok := ks.ParseMatch(producerID, stripped)
+					if !ok || matchID != 123 || remainder != k.remainder {
+
+						t.Errorf("ParseMatch(%d, %s) = %d, %q, %v, want 123, %q, true", producerID, stripped, matchID, remainder, ok, k.remainder)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPatternsSeparateKeys(t *testing.T) {
+
+	for _, l := range layouts {
+
+		t.Run(l.name, func(t *testing.T) {
+
+			t.Setenv("FEEDS_REDIS_KEY_PREFIX", l.keyPrefix)
+
+			ks := l.keyspace
+
+			// market of match 1234 must not be scanned as a market of match 123
+			other := Key(ks.Market(1, 1234, 18, "total=2.5"))
+			if scan(t, Key(ks.MatchPattern(1, 123)), other) {
+
+				t.Errorf("%s matches match pattern %s", other, Key(ks.MatchPattern(1, 123)))
+			}
+
+			// live keys are not keys of the prematch table
+			live := ks.Market(1, 123, 18, "total=2.5")
+			if scan(t, Key(ks.TablePattern(3)), Key(live)) {
+
+				t.Errorf("%s matches table pattern %s", live, Key(ks.TablePattern(3)))
+			}
+
+			if _, _, ok := ks.ParseMatch(3, live); ok {
+
+				t.Errorf("ParseMatch(3, %s) parsed a live key", live)
+			}
+
+			// sequences and change logs are kept by DeleteAll, which deletes the namespace pattern
+			for _, key := range []string{ks.MatchVersion(123), ks.MatchChanges(123), ks.TranslationsVersion()} {
+
+				if scan(t, Key(ks.NamespacePattern()), Key(key)) {
+
+					t.Errorf("%s matches namespace pattern %s", key, Key(ks.NamespacePattern()))
+				}
+			}
+
+			// keys of another tenant are not keys of the namespace
+			tenant := ks.WithPrefix("tenant-b")
+			if scan(t, Key(ks.NamespacePattern()), Key(tenant.Match(1, 123))) {
+
+				t.Errorf("%s matches namespace pattern %s", tenant.Match(1, 123), Key(ks.NamespacePattern()))
+			}
+
+			if _, _, ok := ks.ParseMatch(1, tenant.Match(1, 123)); ok {
+
+				t.Errorf("ParseMatch(1, %s) parsed a key of another tenant", tenant.Match(1, 123))
+			}
+		})
+	}
+}
+
+func TestParseMatchHashTags(t *testing.T) {
+
+	tagged := New("feeds").WithHashTags(true)
+	plain := New("feeds")
+
+	// untagged keys are not keys of a hash tagged keyspace and the other way round
+	if _, _, ok := tagged.ParseMatch(1, plain.Match(1, 123)); ok {
+
+		t.Errorf("hash tagged keyspace parsed %s", plain.Match(1, 123))
+	}
+
+	if _, _, ok := plain.ParseMatch(1, tagged.Match(1, 123)); ok {
+
+		t.Errorf("keyspace parsed hash tagged %s", tagged.Match(1, 123))
+	}
+
+	// all keys of a match share the hash tag so they are stored in one cluster slot
+	for _, key := range append(tagged.MatchKeys(123), tagged.Market(1, 123, 18, "total=2.5"), tagged.MatchVersion(123), tagged.MatchChanges(123)) {
+
+		if !scan(t, "*{match:123}*", key) {
+
+			t.Errorf("%s has no {match:123} hash tag", key)
+		}
+	}
+}
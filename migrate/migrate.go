@@ -0,0 +1,99 @@
+package migrate
+
+import (
+	"log"
+
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/snapshot"
+)
+
+// Mismatch match whose markets or outcomes differ between source and destination after the copy
+type Mismatch struct {
+	MatchID             int64  `json:"match_id"`
+	ProducerID          int64  `json:"producer_id"`
+	SourceMarkets       int    `json:"source_markets"`
+	DestinationMarkets  int    `json:"destination_markets"`
+	SourceOutcomes      int    `json:"source_outcomes"`
+	DestinationOutcomes int    `json:"destination_outcomes"`
+	Error               string `json:"error,omitempty"`
+}
+
+// Report result of a migration
+type Report struct {
+
+	// Records number of match and producer records copied
+	Records int `json:"records"`
+
+	// Markets number of markets copied
+	Markets int `json:"markets"`
+
+	// Outcomes number of outcomes copied
+	Outcomes int `json:"outcomes"`
+
+	// Mismatches records that failed to copy or whose counts differ after the copy
+	Mismatches []Mismatch `json:"mismatches"`
+}
+
+// Copy copies the stored state of the supplied matches from src to dst and verifies market and outcome counts,
+// all matches are copied when no matchID is supplied. The stored markets of each copied match in dst are replaced
+// by the markets of src, so the counts of dst are verified to be exactly the source counts
+func Copy(src, dst feeds.Feed, matchIDs ...int64) *Report {
+
+	report := new(Report)
+
+	for _, record := range snapshot.Read(src, matchIDs...) {
+
+		markets, outcomes := Count(record.Markets)
+
+		mismatch := Mismatch{
+			MatchID:        record.MatchID,
+			ProducerID:     record.ProducerID,
+			SourceMarkets:  markets,
+			SourceOutcomes: outcomes,
+		}
+
+		err := snapshot.Write(dst, record)
+		if err != nil {
+
+			log.Printf("%s", err.Error())
+			mismatch.Error = err.Error()
+			report.Mismatches = append(report.Mismatches, mismatch)
+			continue
+		}
+
+		mismatch.DestinationMarkets, mismatch.DestinationOutcomes = Count(dst.GetStoredMarkets(record.ProducerID, record.MatchID))
+
+		report.Records++
+		report.Markets += markets
+		report.Outcomes += outcomes
+
+		if mismatch.DestinationMarkets != markets || mismatch.DestinationOutcomes != outcomes {
+
+			log.Printf("migration mismatch match %d producer %d | markets %d/%d | outcomes %d/%d", record.MatchID, record.ProducerID,
+				mismatch.DestinationMarkets, markets, mismatch.DestinationOutcomes, outcomes)
+
+			report.Mismatches = append(report.Mismatches, mismatch)
+		}
+	}
+
+	return report
+}
+
+// Count gets the number of markets with outcomes and the total number of outcomes,
+// markets without outcomes are not counted as the mysql backend does not store them
+func Count(markets []models.Market) (marketCount, outcomeCount int) {
+
+	for _, m := range markets {
+
+		if len(m.Outcomes) == 0 {
+
+			continue
+		}
+
+		marketCount++
+		outcomeCount += len(m.Outcomes)
+	}
+
+	return marketCount, outcomeCount
+}
@@ -0,0 +1,22 @@
+package models
+
+// BetStop suspends all markets of a match for a producer
+type BetStop struct {
+
+	// ProducerID producer whose markets are suspended
+	ProducerID int64 `json:"producer_id"`
+
+	// MatchID match whose markets are suspended
+	MatchID int64 `json:"match_id"`
+
+	// Status status set on the markets
+	Status int64 `json:"status"`
+
+	// StatusName name of the status set on the markets
+	StatusName string `json:"status_name"`
+
+	BetradarTimestamp int64 `json:"betradar_timestamp"`
+	PublishTimestamp  int64 `json:"publish_timestamp"`
+	ProcessingTime    int64 `json:"processing_time"`
+	NetworkLatency    int64 `json:"network_latency"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// MatchChanges markets of a match changed after a sequence
+type MatchChanges struct {
+
+	// MatchID match of the changes
+	MatchID int64 `json:"match_id"`
+
+	// ProducerID producer of the markets
+	ProducerID int64 `json:"producer_id"`
+
+	// Sequence sequence of the match the changes reach to, request the next changes since this sequence
+	Sequence int64 `json:"sequence"`
+
+	// FullResync Markets has all markets of the match, replace the markets of the match instead of applying changes
+	FullResync bool `json:"full_resync"`
+
+	// Markets changed markets as they are at Sequence or later
+	Markets []Market `json:"markets"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// OddsInvalidation published after the stored markets of a match change, caches drop the markets of the match
+type OddsInvalidation struct {
+
+	// MatchID match whose markets changed
+	MatchID int64 `json:"match_id"`
+
+	// ProducerID producer whose markets changed, 0 for all producers
+	ProducerID int64 `json:"producer_id"`
+
+	// Sequence sequence of the match after the change
+	Sequence int64 `json:"sequence"`
+
+	// Timestamp time of the change in milliseconds
+	Timestamp int64 `json:"timestamp"`
+}
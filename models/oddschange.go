@@ -54,6 +54,9 @@ type Outcome struct {
 
 	//Probability odds probability
 	Probability float64 `json:"probability"  validate:"required"`
+
+	//FormattedOdds odds in the requested display format, only set when an odds format is requested
+	FormattedOdds string `json:"formatted_odds,omitempty"`
 }
 
 type Market struct {
@@ -97,6 +100,9 @@ type OddsDetails struct {
 	EventType   string  `json:"event_type"`
 	EventPrefix string  `json:"event_prefix"`
 	ProducerID  int64   `json:"producer_id"`
+
+	// FormattedOdds odds in the requested display format, only set when an odds format is requested
+	FormattedOdds string `json:"formatted_odds,omitempty"`
 }
 
 type MarketOrderList struct {
@@ -0,0 +1,117 @@
+package oddsformat
+
+import (
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// FormattedFeed sets formatted_odds in Format on the markets and odds read from the wrapped feed, so reads of the
+// same feed can be served in different formats e.g one FormattedFeed per brand or per request
+type FormattedFeed struct {
+	feeds.Feed
+
+	// Format format of formatted_odds
+	Format Format
+}
+
+// NewFormattedFeed creates a feed that formats the odds read from feed in the supplied format
+func NewFormattedFeed(feed feeds.Feed, format Format) *FormattedFeed {
+
+	return &FormattedFeed{
+		Feed:   feed,
+		Format: format,
+	}
+}
+
+// ConfigVersion gets a hash of the format combined with the configuration version of the wrapped feed
+func (f *FormattedFeed) ConfigVersion() string {
+
+	return feeds.ConfigHash(f.Feed, f.Format)
+}
+
+// GetAllMarkets gets all markets for a particular matchID with formatted odds
+func (f *FormattedFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
+
+	return FormatMarkets(f.Feed.GetAllMarkets(producerID, matchID), f.Format)
+}
+
+// GetAllMarketsWithSequence gets all markets for a particular matchID with formatted odds and the sequence of the match
+func (f *FormattedFeed) GetAllMarketsWithSequence(producerID, matchID int64) ([]models.Market, int64) {
+
+	markets, sequence := f.Feed.GetAllMarketsWithSequence(producerID, matchID)
+	return FormatMarkets(markets, f.Format), sequence
+}
+
+// GetMarketsIfChanged gets all markets for a particular matchID with formatted odds if the match changed since sinceSequence
+func (f *FormattedFeed) GetMarketsIfChanged(producerID, matchID, sinceSequence int64) ([]models.Market, int64, bool) {
+
+	markets, sequence, changed := f.Feed.GetMarketsIfChanged(producerID, matchID, sinceSequence)
+	if !changed {
+
+		return nil, sequence, false
+	}
+
+	return FormatMarkets(markets, f.Format), sequence, true
+}
+
+// GetChangesSince gets the markets of a particular matchID changed after sinceSequence with formatted odds
+func (f *FormattedFeed) GetChangesSince(producerID, matchID, sinceSequence int64) models.MatchChanges {
+
+	changes := f.Feed.GetChangesSince(producerID, matchID, sinceSequence)
+	changes.Markets = FormatMarkets(changes.Markets, f.Format)
+	return changes
+}
+
+// GetMarket gets market for a particular matchID and marketID with formatted odds
+func (f *FormattedFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
+
+	return FormatMarket(f.Feed.GetMarket(producerID, matchID, marketID, specifier), f.Format)
+}
+
+// GetOdds gets formatted odds for the supplied outcome
+func (f *FormattedFeed) GetOdds(matchID, marketID int64, specifier, outcomeID string) *models.OddsDetails {
+
+	return FormatOddsDetails(f.Feed.GetOdds(matchID, marketID, specifier, outcomeID), f.Format)
+}
+
+// GetAllMarketsOrderByList gets all markets order by the supplied list with formatted odds
+func (f *FormattedFeed) GetAllMarketsOrderByList(producerID, matchID int64, marketOderList []models.MarketOrderList) []models.Market {
+
+	return FormatMarkets(f.Feed.GetAllMarketsOrderByList(producerID, matchID, marketOderList), f.Format)
+}
+
+// GetSpecifiedMarkets gets the specified markets with formatted odds
+func (f *FormattedFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList []models.MarketOrderList) []models.Market {
+
+	return FormatMarkets(f.Feed.GetSpecifiedMarkets(producerID, matchID, marketList), f.Format)
+}
+
+// GetAllMarketsByLocale gets all translated markets with formatted odds
+func (f *FormattedFeed) GetAllMarketsByLocale(producerID, matchID int64, locale string) []models.Market {
+
+	return FormatMarkets(f.Feed.GetAllMarketsByLocale(producerID, matchID, locale), f.Format)
+}
+
+// GetMarketByLocale gets translated market with formatted odds
+func (f *FormattedFeed) GetMarketByLocale(producerID, matchID, marketID int64, specifier, locale string) *models.Market {
+
+	return FormatMarket(f.Feed.GetMarketByLocale(producerID, matchID, marketID, specifier, locale), f.Format)
+}
+
+// GetOddsByLocale gets translated odds with formatted odds
+func (f *FormattedFeed) GetOddsByLocale(matchID, marketID int64, specifier, outcomeID, locale string) *models.OddsDetails {
+
+	return FormatOddsDetails(f.Feed.GetOddsByLocale(matchID, marketID, specifier, outcomeID, locale), f.Format)
+}
+
+// GetAllMarketsOrderByListByLocale gets all translated markets order by the supplied list with formatted odds
+func (f *FormattedFeed) GetAllMarketsOrderByListByLocale(producerID, matchID int64, marketOderList []models.MarketOrderList, locale string) []models.Market {
+
+	return FormatMarkets(f.Feed.GetAllMarketsOrderByListByLocale(producerID, matchID, marketOderList, locale), f.Format)
+}
+
+// GetSpecifiedMarketsByLocale gets the specified translated markets with formatted odds
+func (f *FormattedFeed) GetSpecifiedMarketsByLocale(producerID, matchID int64, marketList []models.MarketOrderList, locale string) []models.Market {
+
+	return FormatMarkets(f.Feed.GetSpecifiedMarketsByLocale(producerID, matchID, marketList, locale), f.Format)
+}
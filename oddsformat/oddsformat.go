@@ -0,0 +1,275 @@
+package oddsformat
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// Format odds display format
+type Format string
+
+// Decimal european odds e.g 2.50
+const Decimal Format = "decimal"
+
+// Fractional UK odds e.g 6/4
+const Fractional Format = "fractional"
+
+// American moneyline odds e.g +150 or -200
+const American Format = "american"
+
+// HongKong odds, net return on a stake of 1 e.g 1.50
+const HongKong Format = "hongkong"
+
+// Indonesian odds, positive above even money and negative below e.g 1.50 or -2.00
+const Indonesian Format = "indonesian"
+
+// Malay odds, positive below even money and negative above e.g 0.50 or -0.67
+const Malay Format = "malay"
+
+// ErrInvalidOdds returned when decimal odds are not greater than 1
+var ErrInvalidOdds = errors.New("decimal odds must be greater than 1")
+
+// ErrUnknownFormat returned when the odds format is not supported
+var ErrUnknownFormat = errors.New("unknown odds format")
+
+// fraction is a fractional odds step in the ladder
+type fraction struct {
+	numerator   int64
+	denominator int64
+}
+
+// ladder standard fractional odds ladder in ascending order
+var ladder = []fraction{
+	{1, 100}, {1, 66}, {1, 50}, {1, 40}, {1, 33}, {1, 25}, {1, 20}, {1, 16}, {1, 14}, {1, 12},
+	{1, 11}, {1, 10}, {1, 9}, {1, 8}, {2, 15}, {1, 7}, {2, 13}, {1, 6}, {2, 11}, {1, 5},
+	{2, 9}, {1, 4}, {2, 7}, {3, 10}, {1, 3}, {4, 11}, {2, 5}, {4, 9}, {1, 2}, {8, 15},
+	{4, 7}, {8, 13}, {4, 6}, {8, 11}, {4, 5}, {5, 6}, {10, 11}, {1, 1}, {21, 20}, {11, 10},
+	{6, 5}, {5, 4}, {11, 8}, {7, 5}, {6, 4}, {8, 5}, {13, 8}, {7, 4}, {15, 8}, {2, 1},
+	{85, 40}, {9, 4}, {5, 2}, {11, 4}, {3, 1}, {10, 3}, {7, 2}, {4, 1}, {9, 2}, {5, 1},
+	{11, 2}, {6, 1}, {13, 2}, {7, 1}, {15, 2}, {8, 1}, {17, 2}, {9, 1}, {10, 1}, {11, 1},
+	{12, 1}, {14, 1}, {16, 1}, {18, 1}, {20, 1}, {25, 1}, {33, 1}, {40, 1}, {50, 1}, {66, 1},
+	{80, 1}, {100, 1}, {150, 1}, {200, 1}, {250, 1}, {500, 1}, {1000, 1},
+}
+
+// Parse gets Format from the supplied string, an empty string is parsed as Decimal
+func Parse(format string) (Format, error) {
+
+	switch f := Format(strings.ToLower(strings.TrimSpace(format))); f {
+
+	case "":
+		return Decimal, nil
+
+	case Decimal, Fractional, American, HongKong, Indonesian, Malay:
+		return f, nil
+
+	}
+
+	return "", fmt.Errorf("%w %s", ErrUnknownFormat, format)
+}
+
+// FormatFromEnv gets the odds format configured in ODDS_FORMAT, returns an empty format (odds are not formatted) if not set or invalid
+func FormatFromEnv() Format {
+
+	if len(os.Getenv("ODDS_FORMAT")) == 0 {
+
+		return ""
+	}
+
+	format, err := Parse(os.Getenv("ODDS_FORMAT"))
+	if err != nil {
+
+		log.Printf("invalid ODDS_FORMAT %s ", err.Error())
+		return ""
+	}
+
+	return format
+}
+
+// Convert converts decimal odds to the supplied format
+func Convert(decimal float64, format Format) (string, error) {
+
+	if decimal <= 1 || math.IsNaN(decimal) || math.IsInf(decimal, 0) {
+
+		return "", ErrInvalidOdds
+	}
+
+	switch format {
+
+	case Decimal, "":
+		return fmt.Sprintf("%.2f", round(decimal, 2)), nil
+
+	case Fractional:
+		numerator, denominator := ToFractional(decimal)
+		return fmt.Sprintf("%d/%d", numerator, denominator), nil
+
+	case American:
+		return fmt.Sprintf("%+d", ToAmerican(decimal)), nil
+
+	case HongKong:
+		return fmt.Sprintf("%.2f", ToHongKong(decimal)), nil
+
+	case Indonesian:
+		return fmt.Sprintf("%.2f", ToIndonesian(decimal)), nil
+
+	case Malay:
+		return fmt.Sprintf("%.2f", ToMalay(decimal)), nil
+
+	}
+
+	return "", fmt.Errorf("%w %s", ErrUnknownFormat, format)
+}
+
+// ToFractional converts decimal odds to the nearest fraction in the standard ladder,
+// ties are resolved to the shorter price. Odds outside the ladder are rounded to N/1 or 1/N
+func ToFractional(decimal float64) (numerator, denominator int64) {
+
+	profit := decimal - 1
+
+	first := ladder[0]
+	last := ladder[len(ladder)-1]
+
+	if profit < float64(first.numerator)/float64(first.denominator) {
+
+		return 1, int64(math.Max(1, math.Round(1/profit)))
+	}
+
+	if profit > float64(last.numerator)/float64(last.denominator) {
+
+		return int64(math.Round(profit)), 1
+	}
+
+	best := first
+	bestDiff := math.MaxFloat64
+
+	for _, f := range ladder {
+
+		diff := math.Abs(profit - float64(f.numerator)/float64(f.denominator))
+
+		// ladder is ascending, only replace on a strictly closer step so ties keep the shorter price
+		if diff < bestDiff-1e-9 {
+
+			best = f
+			bestDiff = diff
+		}
+	}
+
+	return best.numerator, best.denominator
+}
+
+// ToAmerican converts decimal odds to american moneyline odds
+func ToAmerican(decimal float64) int64 {
+
+	if decimal >= 2 {
+
+		return int64(math.Round((decimal - 1) * 100))
+	}
+
+	return -int64(math.Round(100 / (decimal - 1)))
+}
+
+// ToHongKong converts decimal odds to hong kong odds
+func ToHongKong(decimal float64) float64 {
+
+	return round(decimal-1, 2)
+}
+
+// ToIndonesian converts decimal odds to indonesian odds
+func ToIndonesian(decimal float64) float64 {
+
+	if decimal >= 2 {
+
+		return round(decimal-1, 2)
+	}
+
+	return round(-1/(decimal-1), 2)
+}
+
+// ToMalay converts decimal odds to malay odds
+func ToMalay(decimal float64) float64 {
+
+	if decimal <= 2 {
+
+		return round(decimal-1, 2)
+	}
+
+	return round(-1/(decimal-1), 2)
+}
+
+// FormatMarkets sets FormattedOdds for all outcomes in the supplied markets, the supplied markets are not modified
+func FormatMarkets(markets []models.Market, format Format) []models.Market {
+
+	if len(format) == 0 || markets == nil {
+
+		return markets
+	}
+
+	formatted := make([]models.Market, len(markets))
+
+	for i, m := range markets {
+
+		formatted[i] = *FormatMarket(&m, format)
+	}
+
+	return formatted
+}
+
+// FormatMarket sets FormattedOdds for all outcomes in the supplied market, the supplied market is not modified
+func FormatMarket(market *models.Market, format Format) *models.Market {
+
+	if len(format) == 0 || market == nil {
+
+		return market
+	}
+
+	formatted := *market
+
+	if market.Outcomes != nil {
+
+		formatted.Outcomes = make([]models.Outcome, len(market.Outcomes))
+
+		for i, o := range market.Outcomes {
+
+			o.FormattedOdds, _ = Convert(o.Odds, format)
+			formatted.Outcomes[i] = o
+		}
+	}
+
+	return &formatted
+}
+
+// FormatOddsDetails sets FormattedOdds for the supplied odds, the supplied odds are not modified
+func FormatOddsDetails(odds *models.OddsDetails, format Format) *models.OddsDetails {
+
+	if len(format) == 0 || odds == nil {
+
+		return odds
+	}
+
+	formatted := *odds
+	formatted.FormattedOdds, _ = Convert(odds.Odds, format)
+
+	return &formatted
+}
+
+// round rounds half away from zero to the supplied decimal places, the small offset corrects
+// binary representation errors e.g 2.675 stored as 2.67499999
+func round(value float64, places int) float64 {
+
+	pow := math.Pow(10, float64(places))
+
+	rounded := math.Round(math.Abs(value)*pow+1e-9) / pow
+
+	// avoid returning negative zero, it is displayed as -0.00
+	if value < 0 && rounded != 0 {
+
+		return -rounded
+	}
+
+	return rounded
+}
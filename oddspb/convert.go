@@ -0,0 +1,298 @@
+package oddspb
+
+import "github.com/touchvas/odds-sdk/v2/models"
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative odds.proto odds_service.proto
+
+// Converters between the SDK models and the protobuf messages. Every field is copied as is so a model converted to
+// a message and back is equal to the original, except empty slices which are returned as nil slices
+
+// FromOutcome converts models.Outcome to an Outcome message
+func FromOutcome(o models.Outcome) *Outcome {
+
+	return &Outcome{
+		OutcomeName:   o.OutcomeName,
+		OutcomeId:     o.OutcomeID,
+		Odds:          o.Odds,
+		Active:        o.Active,
+		Probability:   o.Probability,
+		FormattedOdds: o.FormattedOdds,
+	}
+}
+
+// ToOutcome converts an Outcome message to models.Outcome
+func ToOutcome(o *Outcome) models.Outcome {
+
+	return models.Outcome{
+		OutcomeName:   o.GetOutcomeName(),
+		OutcomeID:     o.GetOutcomeId(),
+		Odds:          o.GetOdds(),
+		Active:        o.GetActive(),
+		Probability:   o.GetProbability(),
+		FormattedOdds: o.GetFormattedOdds(),
+	}
+}
+
+// FromMarket converts models.Market to a Market message
+func FromMarket(m models.Market) *Market {
+
+	market := &Market{
+		MarketUrl:  m.MarketURL,
+		MarketName: m.MarketName,
+		MarketId:   m.MarketID,
+		Specifier:  m.Specifier,
+		StatusName: m.StatusName,
+		Status:     m.Status,
+	}
+
+	for _, o := range m.Outcomes {
+
+		market.Outcomes = append(market.Outcomes, FromOutcome(o))
+	}
+
+	return market
+}
+
+// ToMarket converts a Market message to models.Market
+func ToMarket(m *Market) models.Market {
+
+	market := models.Market{
+		MarketURL:  m.GetMarketUrl(),
+		MarketName: m.GetMarketName(),
+		MarketID:   m.GetMarketId(),
+		Specifier:  m.GetSpecifier(),
+		StatusName: m.GetStatusName(),
+		Status:     m.GetStatus(),
+	}
+
+	for _, o := range m.GetOutcomes() {
+
+		market.Outcomes = append(market.Outcomes, ToOutcome(o))
+	}
+
+	return market
+}
+
+// FromMarkets converts markets to Market messages
+func FromMarkets(markets []models.Market) []*Market {
+
+	var converted []*Market
+
+	for _, m := range markets {
+
+		converted = append(converted, FromMarket(m))
+	}
+
+	return converted
+}
+
+// ToMarkets converts Market messages to markets
+func ToMarkets(markets []*Market) []models.Market {
+
+	var converted []models.Market
+
+	for _, m := range markets {
+
+		converted = append(converted, ToMarket(m))
+	}
+
+	return converted
+}
+
+// FromOddsDetails converts models.OddsDetails to an OddsDetails message, nil stays nil
+func FromOddsDetails(o *models.OddsDetails) *OddsDetails {
+
+	if o == nil {
+
+		return nil
+	}
+
+	return &OddsDetails{
+		SportId:       o.SportID,
+		MatchId:       o.MatchID,
+		MarketId:      o.MarketID,
+		MarketName:    o.MarketName,
+		Specifier:     o.Specifier,
+		OutcomeId:     o.OutcomeID,
+		OutcomeName:   o.OutcomeName,
+		Status:        o.Status,
+		Active:        o.Active,
+		StatusName:    o.StatusName,
+		Odds:          o.Odds,
+		Probability:   o.Probability,
+		Event:         o.Event,
+		EventType:     o.EventType,
+		EventPrefix:   o.EventPrefix,
+		ProducerId:    o.ProducerID,
+		FormattedOdds: o.FormattedOdds,
+	}
+}
+
+// ToOddsDetails converts an OddsDetails message to models.OddsDetails, nil stays nil
+func ToOddsDetails(o *OddsDetails) *models.OddsDetails {
+
+	if o == nil {
+
+		return nil
+	}
+
+	return &models.OddsDetails{
+		SportID:       o.GetSportId(),
+		MatchID:       o.GetMatchId(),
+		MarketID:      o.GetMarketId(),
+		MarketName:    o.GetMarketName(),
+		Specifier:     o.GetSpecifier(),
+		OutcomeID:     o.GetOutcomeId(),
+		OutcomeName:   o.GetOutcomeName(),
+		Status:        o.GetStatus(),
+		Active:        o.GetActive(),
+		StatusName:    o.GetStatusName(),
+		Odds:          o.GetOdds(),
+		Probability:   o.GetProbability(),
+		Event:         o.GetEvent(),
+		EventType:     o.GetEventType(),
+		EventPrefix:   o.GetEventPrefix(),
+		ProducerID:    o.GetProducerId(),
+		FormattedOdds: o.GetFormattedOdds(),
+	}
+}
+
+// FromFixtureStatus converts models.FixtureStatus to a FixtureStatus message
+func FromFixtureStatus(fx models.FixtureStatus) *FixtureStatus {
+
+	return &FixtureStatus{
+		EventId:          fx.EventID,
+		EventPrefix:      fx.EventPrefix,
+		SportId:          fx.SportID,
+		Status:           fx.Status,
+		StatusName:       fx.StatusName,
+		HomeScore:        fx.HomeScore,
+		AwayScore:        fx.AwayScore,
+		MatchStatus:      fx.MatchStatus,
+		StatusCode:       fx.StatusCode,
+		EventTime:        fx.EventTime,
+		HomePenaltyScore: fx.HomePenaltyScore,
+		AwayPenaltyScore: fx.AwayPenaltyScore,
+		ActiveMarkets:    fx.ActiveMarkets,
+	}
+}
+
+// ToFixtureStatus converts a FixtureStatus message to models.FixtureStatus
+func ToFixtureStatus(fx *FixtureStatus) models.FixtureStatus {
+
+	return models.FixtureStatus{
+		EventID:          fx.GetEventId(),
+		EventPrefix:      fx.GetEventPrefix(),
+		SportID:          fx.GetSportId(),
+		Status:           fx.GetStatus(),
+		StatusName:       fx.GetStatusName(),
+		HomeScore:        fx.GetHomeScore(),
+		AwayScore:        fx.GetAwayScore(),
+		MatchStatus:      fx.GetMatchStatus(),
+		StatusCode:       fx.GetStatusCode(),
+		EventTime:        fx.GetEventTime(),
+		HomePenaltyScore: fx.GetHomePenaltyScore(),
+		AwayPenaltyScore: fx.GetAwayPenaltyScore(),
+		ActiveMarkets:    fx.GetActiveMarkets(),
+	}
+}
+
+// FromOddsChange converts models.OddsChange to an OddsChange message
+func FromOddsChange(odds models.OddsChange) *OddsChange {
+
+	return &OddsChange{
+		ConsumerArrivalTime: odds.ConsumerArrivalTime,
+		ProducerStatus:      odds.ProducerStatus,
+		PublishTimestamp:    odds.PublishTimestamp,
+		BetradarTimestamp:   odds.BetradarTimestamp,
+		ProcessingTime:      odds.ProcessingTime,
+		ReceivedTimestamp:   odds.ReceivedTimestamp,
+		NetworkLatency:      odds.NetworkLatency,
+		Event:               odds.Event,
+		EventId:             odds.EventID,
+		EventType:           odds.EventType,
+		EventPrefix:         odds.EventPrefix,
+		MatchId:             odds.MatchID,
+		ProducerName:        odds.ProducerName,
+		ProducerId:          odds.ProducerID,
+		SportId:             odds.SportID,
+		StatusName:          odds.StatusName,
+		Status:              odds.Status,
+		Markets:             FromMarkets(odds.Markets),
+		FixtureStatus:       FromFixtureStatus(odds.FixtureStatus),
+	}
+}
+
+// ToOddsChange converts an OddsChange message to models.OddsChange
+func ToOddsChange(odds *OddsChange) models.OddsChange {
+
+	return models.OddsChange{
+		ConsumerArrivalTime: odds.GetConsumerArrivalTime(),
+		ProducerStatus:      odds.GetProducerStatus(),
+		PublishTimestamp:    odds.GetPublishTimestamp(),
+		BetradarTimestamp:   odds.GetBetradarTimestamp(),
+		ProcessingTime:      odds.GetProcessingTime(),
+		ReceivedTimestamp:   odds.GetReceivedTimestamp(),
+		NetworkLatency:      odds.GetNetworkLatency(),
+		Event:               odds.GetEvent(),
+		EventID:             odds.GetEventId(),
+		EventType:           odds.GetEventType(),
+		EventPrefix:         odds.GetEventPrefix(),
+		MatchID:             odds.GetMatchId(),
+		ProducerName:        odds.GetProducerName(),
+		ProducerID:          odds.GetProducerId(),
+		SportID:             odds.GetSportId(),
+		StatusName:          odds.GetStatusName(),
+		Status:              odds.GetStatus(),
+		Markets:             ToMarkets(odds.GetMarkets()),
+		FixtureStatus:       ToFixtureStatus(odds.GetFixtureStatus()),
+	}
+}
+
+// FromMarketOrderList converts an ordered market list to MarketOrderList messages
+func FromMarketOrderList(list []models.MarketOrderList) []*MarketOrderList {
+
+	var converted []*MarketOrderList
+
+	for _, m := range list {
+
+		converted = append(converted, &MarketOrderList{MarketId: m.MarketID, MarketName: m.MarketName})
+	}
+
+	return converted
+}
+
+// ToMarketOrderList converts MarketOrderList messages to an ordered market list
+func ToMarketOrderList(list []*MarketOrderList) []models.MarketOrderList {
+
+	var converted []models.MarketOrderList
+
+	for _, m := range list {
+
+		converted = append(converted, models.MarketOrderList{MarketID: m.GetMarketId(), MarketName: m.GetMarketName()})
+	}
+
+	return converted
+}
+
+// FromOddsInvalidation converts models.OddsInvalidation to an OddsInvalidation message
+func FromOddsInvalidation(i models.OddsInvalidation) *OddsInvalidation {
+
+	return &OddsInvalidation{
+		MatchId:    i.MatchID,
+		ProducerId: i.ProducerID,
+		Sequence:   i.Sequence,
+		Timestamp:  i.Timestamp,
+	}
+}
+
+// ToOddsInvalidation converts an OddsInvalidation message to models.OddsInvalidation
+func ToOddsInvalidation(i *OddsInvalidation) models.OddsInvalidation {
+
+	return models.OddsInvalidation{
+		MatchID:    i.GetMatchId(),
+		ProducerID: i.GetProducerId(),
+		Sequence:   i.GetSequence(),
+		Timestamp:  i.GetTimestamp(),
+	}
+}
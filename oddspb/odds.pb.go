@@ -0,0 +1,1043 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: odds.proto
+
+package oddspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Outcome selection of a market, see models.Outcome
+type Outcome struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OutcomeName string                 `protobuf:"bytes,1,opt,name=outcome_name,json=outcomeName,proto3" json:"outcome_name,omitempty"`
+	OutcomeId   string                 `protobuf:"bytes,2,opt,name=outcome_id,json=outcomeId,proto3" json:"outcome_id,omitempty"`
+	Odds        float64                `protobuf:"fixed64,3,opt,name=odds,proto3" json:"odds,omitempty"`
+	// active 1 when the odds can be displayed
+	Active      int64   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	Probability float64 `protobuf:"fixed64,5,opt,name=probability,proto3" json:"probability,omitempty"`
+	// formatted_odds odds in the requested display format, only set when an odds format is requested
+	FormattedOdds string `protobuf:"bytes,6,opt,name=formatted_odds,json=formattedOdds,proto3" json:"formatted_odds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Outcome) Reset() {
+	*x = Outcome{}
+	mi := &file_odds_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Outcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Outcome) ProtoMessage() {}
+
+func (x *Outcome) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Outcome.ProtoReflect.Descriptor instead.
+func (*Outcome) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Outcome) GetOutcomeName() string {
+	if x != nil {
+		return x.OutcomeName
+	}
+	return ""
+}
+
+func (x *Outcome) GetOutcomeId() string {
+	if x != nil {
+		return x.OutcomeId
+	}
+	return ""
+}
+
+func (x *Outcome) GetOdds() float64 {
+	if x != nil {
+		return x.Odds
+	}
+	return 0
+}
+
+func (x *Outcome) GetActive() int64 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *Outcome) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *Outcome) GetFormattedOdds() string {
+	if x != nil {
+		return x.FormattedOdds
+	}
+	return ""
+}
+
+// Market market of a match, see models.Market.
+// Field numbers are the ones of the protobuf codec of stored markets
+type Market struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MarketUrl  string                 `protobuf:"bytes,1,opt,name=market_url,json=marketUrl,proto3" json:"market_url,omitempty"`
+	MarketName string                 `protobuf:"bytes,2,opt,name=market_name,json=marketName,proto3" json:"market_name,omitempty"`
+	MarketId   int64                  `protobuf:"varint,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Specifier  string                 `protobuf:"bytes,4,opt,name=specifier,proto3" json:"specifier,omitempty"`
+	StatusName string                 `protobuf:"bytes,5,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	// status 0 (active) else suspended
+	Status        int64      `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+	Outcomes      []*Outcome `protobuf:"bytes,7,rep,name=outcomes,proto3" json:"outcomes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Market) Reset() {
+	*x = Market{}
+	mi := &file_odds_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Market) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Market) ProtoMessage() {}
+
+func (x *Market) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Market.ProtoReflect.Descriptor instead.
+func (*Market) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Market) GetMarketUrl() string {
+	if x != nil {
+		return x.MarketUrl
+	}
+	return ""
+}
+
+func (x *Market) GetMarketName() string {
+	if x != nil {
+		return x.MarketName
+	}
+	return ""
+}
+
+func (x *Market) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *Market) GetSpecifier() string {
+	if x != nil {
+		return x.Specifier
+	}
+	return ""
+}
+
+func (x *Market) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *Market) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Market) GetOutcomes() []*Outcome {
+	if x != nil {
+		return x.Outcomes
+	}
+	return nil
+}
+
+// MarketList markets of a match as stored by the protobuf codec
+type MarketList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Markets       []*Market              `protobuf:"bytes,1,rep,name=markets,proto3" json:"markets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketList) Reset() {
+	*x = MarketList{}
+	mi := &file_odds_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketList) ProtoMessage() {}
+
+func (x *MarketList) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketList.ProtoReflect.Descriptor instead.
+func (*MarketList) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MarketList) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+// OddsDetails odds of an outcome, see models.OddsDetails
+type OddsDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SportId       int64                  `protobuf:"varint,1,opt,name=sport_id,json=sportId,proto3" json:"sport_id,omitempty"`
+	MatchId       int64                  `protobuf:"varint,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	MarketId      int64                  `protobuf:"varint,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	MarketName    string                 `protobuf:"bytes,4,opt,name=market_name,json=marketName,proto3" json:"market_name,omitempty"`
+	Specifier     string                 `protobuf:"bytes,5,opt,name=specifier,proto3" json:"specifier,omitempty"`
+	OutcomeId     string                 `protobuf:"bytes,6,opt,name=outcome_id,json=outcomeId,proto3" json:"outcome_id,omitempty"`
+	OutcomeName   string                 `protobuf:"bytes,7,opt,name=outcome_name,json=outcomeName,proto3" json:"outcome_name,omitempty"`
+	Status        int64                  `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`
+	Active        int64                  `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`
+	StatusName    string                 `protobuf:"bytes,10,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	Odds          float64                `protobuf:"fixed64,11,opt,name=odds,proto3" json:"odds,omitempty"`
+	Probability   float64                `protobuf:"fixed64,12,opt,name=probability,proto3" json:"probability,omitempty"`
+	Event         string                 `protobuf:"bytes,13,opt,name=event,proto3" json:"event,omitempty"`
+	EventType     string                 `protobuf:"bytes,14,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventPrefix   string                 `protobuf:"bytes,15,opt,name=event_prefix,json=eventPrefix,proto3" json:"event_prefix,omitempty"`
+	ProducerId    int64                  `protobuf:"varint,16,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	FormattedOdds string                 `protobuf:"bytes,17,opt,name=formatted_odds,json=formattedOdds,proto3" json:"formatted_odds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OddsDetails) Reset() {
+	*x = OddsDetails{}
+	mi := &file_odds_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OddsDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OddsDetails) ProtoMessage() {}
+
+func (x *OddsDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OddsDetails.ProtoReflect.Descriptor instead.
+func (*OddsDetails) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OddsDetails) GetSportId() int64 {
+	if x != nil {
+		return x.SportId
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetMarketName() string {
+	if x != nil {
+		return x.MarketName
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetSpecifier() string {
+	if x != nil {
+		return x.Specifier
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetOutcomeId() string {
+	if x != nil {
+		return x.OutcomeId
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetOutcomeName() string {
+	if x != nil {
+		return x.OutcomeName
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetActive() int64 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetOdds() float64 {
+	if x != nil {
+		return x.Odds
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetEventPrefix() string {
+	if x != nil {
+		return x.EventPrefix
+	}
+	return ""
+}
+
+func (x *OddsDetails) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *OddsDetails) GetFormattedOdds() string {
+	if x != nil {
+		return x.FormattedOdds
+	}
+	return ""
+}
+
+// FixtureStatus status and score of a match, see models.FixtureStatus
+type FixtureStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EventId          int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventPrefix      string                 `protobuf:"bytes,2,opt,name=event_prefix,json=eventPrefix,proto3" json:"event_prefix,omitempty"`
+	SportId          int64                  `protobuf:"varint,3,opt,name=sport_id,json=sportId,proto3" json:"sport_id,omitempty"`
+	Status           int64                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	StatusName       string                 `protobuf:"bytes,5,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	HomeScore        string                 `protobuf:"bytes,6,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
+	AwayScore        string                 `protobuf:"bytes,7,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
+	MatchStatus      string                 `protobuf:"bytes,8,opt,name=match_status,json=matchStatus,proto3" json:"match_status,omitempty"`
+	StatusCode       int64                  `protobuf:"varint,9,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	EventTime        string                 `protobuf:"bytes,10,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	HomePenaltyScore int64                  `protobuf:"varint,11,opt,name=home_penalty_score,json=homePenaltyScore,proto3" json:"home_penalty_score,omitempty"`
+	AwayPenaltyScore int64                  `protobuf:"varint,12,opt,name=away_penalty_score,json=awayPenaltyScore,proto3" json:"away_penalty_score,omitempty"`
+	ActiveMarkets    int64                  `protobuf:"varint,13,opt,name=active_markets,json=activeMarkets,proto3" json:"active_markets,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FixtureStatus) Reset() {
+	*x = FixtureStatus{}
+	mi := &file_odds_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FixtureStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FixtureStatus) ProtoMessage() {}
+
+func (x *FixtureStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FixtureStatus.ProtoReflect.Descriptor instead.
+func (*FixtureStatus) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FixtureStatus) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *FixtureStatus) GetEventPrefix() string {
+	if x != nil {
+		return x.EventPrefix
+	}
+	return ""
+}
+
+func (x *FixtureStatus) GetSportId() int64 {
+	if x != nil {
+		return x.SportId
+	}
+	return 0
+}
+
+func (x *FixtureStatus) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *FixtureStatus) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *FixtureStatus) GetHomeScore() string {
+	if x != nil {
+		return x.HomeScore
+	}
+	return ""
+}
+
+func (x *FixtureStatus) GetAwayScore() string {
+	if x != nil {
+		return x.AwayScore
+	}
+	return ""
+}
+
+func (x *FixtureStatus) GetMatchStatus() string {
+	if x != nil {
+		return x.MatchStatus
+	}
+	return ""
+}
+
+func (x *FixtureStatus) GetStatusCode() int64 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *FixtureStatus) GetEventTime() string {
+	if x != nil {
+		return x.EventTime
+	}
+	return ""
+}
+
+func (x *FixtureStatus) GetHomePenaltyScore() int64 {
+	if x != nil {
+		return x.HomePenaltyScore
+	}
+	return 0
+}
+
+func (x *FixtureStatus) GetAwayPenaltyScore() int64 {
+	if x != nil {
+		return x.AwayPenaltyScore
+	}
+	return 0
+}
+
+func (x *FixtureStatus) GetActiveMarkets() int64 {
+	if x != nil {
+		return x.ActiveMarkets
+	}
+	return 0
+}
+
+// OddsChange odds change message, see models.OddsChange
+type OddsChange struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerArrivalTime int64                  `protobuf:"varint,1,opt,name=consumer_arrival_time,json=consumerArrivalTime,proto3" json:"consumer_arrival_time,omitempty"`
+	ProducerStatus      int64                  `protobuf:"varint,2,opt,name=producer_status,json=producerStatus,proto3" json:"producer_status,omitempty"`
+	PublishTimestamp    int64                  `protobuf:"varint,3,opt,name=publish_timestamp,json=publishTimestamp,proto3" json:"publish_timestamp,omitempty"`
+	BetradarTimestamp   int64                  `protobuf:"varint,4,opt,name=betradar_timestamp,json=betradarTimestamp,proto3" json:"betradar_timestamp,omitempty"`
+	ProcessingTime      int64                  `protobuf:"varint,5,opt,name=processing_time,json=processingTime,proto3" json:"processing_time,omitempty"`
+	ReceivedTimestamp   int64                  `protobuf:"varint,6,opt,name=received_timestamp,json=receivedTimestamp,proto3" json:"received_timestamp,omitempty"`
+	NetworkLatency      int64                  `protobuf:"varint,7,opt,name=network_latency,json=networkLatency,proto3" json:"network_latency,omitempty"`
+	Event               string                 `protobuf:"bytes,8,opt,name=event,proto3" json:"event,omitempty"`
+	EventId             int64                  `protobuf:"varint,9,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType           string                 `protobuf:"bytes,10,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventPrefix         string                 `protobuf:"bytes,11,opt,name=event_prefix,json=eventPrefix,proto3" json:"event_prefix,omitempty"`
+	MatchId             int64                  `protobuf:"varint,12,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ProducerName        string                 `protobuf:"bytes,13,opt,name=producer_name,json=producerName,proto3" json:"producer_name,omitempty"`
+	ProducerId          int64                  `protobuf:"varint,14,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	SportId             int64                  `protobuf:"varint,15,opt,name=sport_id,json=sportId,proto3" json:"sport_id,omitempty"`
+	StatusName          string                 `protobuf:"bytes,16,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	Status              int64                  `protobuf:"varint,17,opt,name=status,proto3" json:"status,omitempty"`
+	Markets             []*Market              `protobuf:"bytes,18,rep,name=markets,proto3" json:"markets,omitempty"`
+	FixtureStatus       *FixtureStatus         `protobuf:"bytes,19,opt,name=fixture_status,json=fixtureStatus,proto3" json:"fixture_status,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OddsChange) Reset() {
+	*x = OddsChange{}
+	mi := &file_odds_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OddsChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OddsChange) ProtoMessage() {}
+
+func (x *OddsChange) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OddsChange.ProtoReflect.Descriptor instead.
+func (*OddsChange) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OddsChange) GetConsumerArrivalTime() int64 {
+	if x != nil {
+		return x.ConsumerArrivalTime
+	}
+	return 0
+}
+
+func (x *OddsChange) GetProducerStatus() int64 {
+	if x != nil {
+		return x.ProducerStatus
+	}
+	return 0
+}
+
+func (x *OddsChange) GetPublishTimestamp() int64 {
+	if x != nil {
+		return x.PublishTimestamp
+	}
+	return 0
+}
+
+func (x *OddsChange) GetBetradarTimestamp() int64 {
+	if x != nil {
+		return x.BetradarTimestamp
+	}
+	return 0
+}
+
+func (x *OddsChange) GetProcessingTime() int64 {
+	if x != nil {
+		return x.ProcessingTime
+	}
+	return 0
+}
+
+func (x *OddsChange) GetReceivedTimestamp() int64 {
+	if x != nil {
+		return x.ReceivedTimestamp
+	}
+	return 0
+}
+
+func (x *OddsChange) GetNetworkLatency() int64 {
+	if x != nil {
+		return x.NetworkLatency
+	}
+	return 0
+}
+
+func (x *OddsChange) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *OddsChange) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *OddsChange) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OddsChange) GetEventPrefix() string {
+	if x != nil {
+		return x.EventPrefix
+	}
+	return ""
+}
+
+func (x *OddsChange) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *OddsChange) GetProducerName() string {
+	if x != nil {
+		return x.ProducerName
+	}
+	return ""
+}
+
+func (x *OddsChange) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *OddsChange) GetSportId() int64 {
+	if x != nil {
+		return x.SportId
+	}
+	return 0
+}
+
+func (x *OddsChange) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *OddsChange) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *OddsChange) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *OddsChange) GetFixtureStatus() *FixtureStatus {
+	if x != nil {
+		return x.FixtureStatus
+	}
+	return nil
+}
+
+// MarketOrderList market of an ordered market list, see models.MarketOrderList
+type MarketOrderList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarketId      int64                  `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	MarketName    string                 `protobuf:"bytes,2,opt,name=market_name,json=marketName,proto3" json:"market_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketOrderList) Reset() {
+	*x = MarketOrderList{}
+	mi := &file_odds_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketOrderList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketOrderList) ProtoMessage() {}
+
+func (x *MarketOrderList) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketOrderList.ProtoReflect.Descriptor instead.
+func (*MarketOrderList) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MarketOrderList) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *MarketOrderList) GetMarketName() string {
+	if x != nil {
+		return x.MarketName
+	}
+	return ""
+}
+
+// OddsInvalidation published after the stored markets of a match change, see models.OddsInvalidation
+type OddsInvalidation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	MatchId int64                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// producer_id 0 for all producers
+	ProducerId int64 `protobuf:"varint,2,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	Timestamp  int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// sequence sequence of the match after the change
+	Sequence      int64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OddsInvalidation) Reset() {
+	*x = OddsInvalidation{}
+	mi := &file_odds_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OddsInvalidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OddsInvalidation) ProtoMessage() {}
+
+func (x *OddsInvalidation) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OddsInvalidation.ProtoReflect.Descriptor instead.
+func (*OddsInvalidation) Descriptor() ([]byte, []int) {
+	return file_odds_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OddsInvalidation) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *OddsInvalidation) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *OddsInvalidation) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *OddsInvalidation) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+var File_odds_proto protoreflect.FileDescriptor
+
+const file_odds_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"odds.proto\x12\x10touchvas.odds.v1\"\xc0\x01\n" +
+	"\aOutcome\x12!\n" +
+	"\foutcome_name\x18\x01 \x01(\tR\voutcomeName\x12\x1d\n" +
+	"\n" +
+	"outcome_id\x18\x02 \x01(\tR\toutcomeId\x12\x12\n" +
+	"\x04odds\x18\x03 \x01(\x01R\x04odds\x12\x16\n" +
+	"\x06active\x18\x04 \x01(\x03R\x06active\x12 \n" +
+	"\vprobability\x18\x05 \x01(\x01R\vprobability\x12%\n" +
+	"\x0eformatted_odds\x18\x06 \x01(\tR\rformattedOdds\"\xf3\x01\n" +
+	"\x06Market\x12\x1d\n" +
+	"\n" +
+	"market_url\x18\x01 \x01(\tR\tmarketUrl\x12\x1f\n" +
+	"\vmarket_name\x18\x02 \x01(\tR\n" +
+	"marketName\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\x03R\bmarketId\x12\x1c\n" +
+	"\tspecifier\x18\x04 \x01(\tR\tspecifier\x12\x1f\n" +
+	"\vstatus_name\x18\x05 \x01(\tR\n" +
+	"statusName\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\x03R\x06status\x125\n" +
+	"\boutcomes\x18\a \x03(\v2\x19.touchvas.odds.v1.OutcomeR\boutcomes\"@\n" +
+	"\n" +
+	"MarketList\x122\n" +
+	"\amarkets\x18\x01 \x03(\v2\x18.touchvas.odds.v1.MarketR\amarkets\"\x88\x04\n" +
+	"\vOddsDetails\x12\x19\n" +
+	"\bsport_id\x18\x01 \x01(\x03R\asportId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\x03R\amatchId\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\x03R\bmarketId\x12\x1f\n" +
+	"\vmarket_name\x18\x04 \x01(\tR\n" +
+	"marketName\x12\x1c\n" +
+	"\tspecifier\x18\x05 \x01(\tR\tspecifier\x12\x1d\n" +
+	"\n" +
+	"outcome_id\x18\x06 \x01(\tR\toutcomeId\x12!\n" +
+	"\foutcome_name\x18\a \x01(\tR\voutcomeName\x12\x16\n" +
+	"\x06status\x18\b \x01(\x03R\x06status\x12\x16\n" +
+	"\x06active\x18\t \x01(\x03R\x06active\x12\x1f\n" +
+	"\vstatus_name\x18\n" +
+	" \x01(\tR\n" +
+	"statusName\x12\x12\n" +
+	"\x04odds\x18\v \x01(\x01R\x04odds\x12 \n" +
+	"\vprobability\x18\f \x01(\x01R\vprobability\x12\x14\n" +
+	"\x05event\x18\r \x01(\tR\x05event\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x0e \x01(\tR\teventType\x12!\n" +
+	"\fevent_prefix\x18\x0f \x01(\tR\veventPrefix\x12\x1f\n" +
+	"\vproducer_id\x18\x10 \x01(\x03R\n" +
+	"producerId\x12%\n" +
+	"\x0eformatted_odds\x18\x11 \x01(\tR\rformattedOdds\"\xc5\x03\n" +
+	"\rFixtureStatus\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12!\n" +
+	"\fevent_prefix\x18\x02 \x01(\tR\veventPrefix\x12\x19\n" +
+	"\bsport_id\x18\x03 \x01(\x03R\asportId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\x03R\x06status\x12\x1f\n" +
+	"\vstatus_name\x18\x05 \x01(\tR\n" +
+	"statusName\x12\x1d\n" +
+	"\n" +
+	"home_score\x18\x06 \x01(\tR\thomeScore\x12\x1d\n" +
+	"\n" +
+	"away_score\x18\a \x01(\tR\tawayScore\x12!\n" +
+	"\fmatch_status\x18\b \x01(\tR\vmatchStatus\x12\x1f\n" +
+	"\vstatus_code\x18\t \x01(\x03R\n" +
+	"statusCode\x12\x1d\n" +
+	"\n" +
+	"event_time\x18\n" +
+	" \x01(\tR\teventTime\x12,\n" +
+	"\x12home_penalty_score\x18\v \x01(\x03R\x10homePenaltyScore\x12,\n" +
+	"\x12away_penalty_score\x18\f \x01(\x03R\x10awayPenaltyScore\x12%\n" +
+	"\x0eactive_markets\x18\r \x01(\x03R\ractiveMarkets\"\xea\x05\n" +
+	"\n" +
+	"OddsChange\x122\n" +
+	"\x15consumer_arrival_time\x18\x01 \x01(\x03R\x13consumerArrivalTime\x12'\n" +
+	"\x0fproducer_status\x18\x02 \x01(\x03R\x0eproducerStatus\x12+\n" +
+	"\x11publish_timestamp\x18\x03 \x01(\x03R\x10publishTimestamp\x12-\n" +
+	"\x12betradar_timestamp\x18\x04 \x01(\x03R\x11betradarTimestamp\x12'\n" +
+	"\x0fprocessing_time\x18\x05 \x01(\x03R\x0eprocessingTime\x12-\n" +
+	"\x12received_timestamp\x18\x06 \x01(\x03R\x11receivedTimestamp\x12'\n" +
+	"\x0fnetwork_latency\x18\a \x01(\x03R\x0enetworkLatency\x12\x14\n" +
+	"\x05event\x18\b \x01(\tR\x05event\x12\x19\n" +
+	"\bevent_id\x18\t \x01(\x03R\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\n" +
+	" \x01(\tR\teventType\x12!\n" +
+	"\fevent_prefix\x18\v \x01(\tR\veventPrefix\x12\x19\n" +
+	"\bmatch_id\x18\f \x01(\x03R\amatchId\x12#\n" +
+	"\rproducer_name\x18\r \x01(\tR\fproducerName\x12\x1f\n" +
+	"\vproducer_id\x18\x0e \x01(\x03R\n" +
+	"producerId\x12\x19\n" +
+	"\bsport_id\x18\x0f \x01(\x03R\asportId\x12\x1f\n" +
+	"\vstatus_name\x18\x10 \x01(\tR\n" +
+	"statusName\x12\x16\n" +
+	"\x06status\x18\x11 \x01(\x03R\x06status\x122\n" +
+	"\amarkets\x18\x12 \x03(\v2\x18.touchvas.odds.v1.MarketR\amarkets\x12F\n" +
+	"\x0efixture_status\x18\x13 \x01(\v2\x1f.touchvas.odds.v1.FixtureStatusR\rfixtureStatus\"O\n" +
+	"\x0fMarketOrderList\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\x03R\bmarketId\x12\x1f\n" +
+	"\vmarket_name\x18\x02 \x01(\tR\n" +
+	"marketName\"\x88\x01\n" +
+	"\x10OddsInvalidation\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x03R\amatchId\x12\x1f\n" +
+	"\vproducer_id\x18\x02 \x01(\x03R\n" +
+	"producerId\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x03R\bsequenceB(Z&github.com/touchvas/odds-sdk/v2/oddspbb\x06proto3"
+
+var (
+	file_odds_proto_rawDescOnce sync.Once
+	file_odds_proto_rawDescData []byte
+)
+
+func file_odds_proto_rawDescGZIP() []byte {
+	file_odds_proto_rawDescOnce.Do(func() {
+		file_odds_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_odds_proto_rawDesc), len(file_odds_proto_rawDesc)))
+	})
+	return file_odds_proto_rawDescData
+}
+
+var file_odds_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_odds_proto_goTypes = []any{
+	(*Outcome)(nil),          // 0: touchvas.odds.v1.Outcome
+	(*Market)(nil),           // 1: touchvas.odds.v1.Market
+	(*MarketList)(nil),       // 2: touchvas.odds.v1.MarketList
+	(*OddsDetails)(nil),      // 3: touchvas.odds.v1.OddsDetails
+	(*FixtureStatus)(nil),    // 4: touchvas.odds.v1.FixtureStatus
+	(*OddsChange)(nil),       // 5: touchvas.odds.v1.OddsChange
+	(*MarketOrderList)(nil),  // 6: touchvas.odds.v1.MarketOrderList
+	(*OddsInvalidation)(nil), // 7: touchvas.odds.v1.OddsInvalidation
+}
+var file_odds_proto_depIdxs = []int32{
+	0, // 0: touchvas.odds.v1.Market.outcomes:type_name -> touchvas.odds.v1.Outcome
+	1, // 1: touchvas.odds.v1.MarketList.markets:type_name -> touchvas.odds.v1.Market
+	1, // 2: touchvas.odds.v1.OddsChange.markets:type_name -> touchvas.odds.v1.Market
+	4, // 3: touchvas.odds.v1.OddsChange.fixture_status:type_name -> touchvas.odds.v1.FixtureStatus
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_odds_proto_init() }
+func file_odds_proto_init() {
+	if File_odds_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_odds_proto_rawDesc), len(file_odds_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_odds_proto_goTypes,
+		DependencyIndexes: file_odds_proto_depIdxs,
+		MessageInfos:      file_odds_proto_msgTypes,
+	}.Build()
+	File_odds_proto = out.File
+	file_odds_proto_goTypes = nil
+	file_odds_proto_depIdxs = nil
+}
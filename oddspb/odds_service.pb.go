@@ -0,0 +1,694 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: odds_service.proto
+
+package oddspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MatchRequest producer_id 0 reads the markets of the active producer of the match
+type MatchRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProducerId int64                  `protobuf:"varint,1,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	MatchId    int64                  `protobuf:"varint,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// locale translates market and outcome names when set
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchRequest) Reset() {
+	*x = MatchRequest{}
+	mi := &file_odds_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRequest) ProtoMessage() {}
+
+func (x *MatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRequest.ProtoReflect.Descriptor instead.
+func (*MatchRequest) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MatchRequest) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *MatchRequest) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *MatchRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type MatchMarkets struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MatchId    int64                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ProducerId int64                  `protobuf:"varint,2,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	Markets    []*Market              `protobuf:"bytes,3,rep,name=markets,proto3" json:"markets,omitempty"`
+	// timestamp time the markets were read in milliseconds
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// sequence sequence of the match the markets were read at
+	Sequence      int64 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchMarkets) Reset() {
+	*x = MatchMarkets{}
+	mi := &file_odds_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchMarkets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchMarkets) ProtoMessage() {}
+
+func (x *MatchMarkets) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchMarkets.ProtoReflect.Descriptor instead.
+func (*MatchMarkets) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MatchMarkets) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *MatchMarkets) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *MatchMarkets) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *MatchMarkets) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MatchMarkets) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// MarketRequest producer_id 0 reads the market of the active producer of the match
+type MarketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProducerId    int64                  `protobuf:"varint,1,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	MatchId       int64                  `protobuf:"varint,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	MarketId      int64                  `protobuf:"varint,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Specifier     string                 `protobuf:"bytes,4,opt,name=specifier,proto3" json:"specifier,omitempty"`
+	Locale        string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketRequest) Reset() {
+	*x = MarketRequest{}
+	mi := &file_odds_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketRequest) ProtoMessage() {}
+
+func (x *MarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketRequest.ProtoReflect.Descriptor instead.
+func (*MarketRequest) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MarketRequest) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *MarketRequest) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *MarketRequest) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *MarketRequest) GetSpecifier() string {
+	if x != nil {
+		return x.Specifier
+	}
+	return ""
+}
+
+func (x *MarketRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type OddsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       int64                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	MarketId      int64                  `protobuf:"varint,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Specifier     string                 `protobuf:"bytes,3,opt,name=specifier,proto3" json:"specifier,omitempty"`
+	OutcomeId     string                 `protobuf:"bytes,4,opt,name=outcome_id,json=outcomeId,proto3" json:"outcome_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OddsRequest) Reset() {
+	*x = OddsRequest{}
+	mi := &file_odds_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OddsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OddsRequest) ProtoMessage() {}
+
+func (x *OddsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OddsRequest.ProtoReflect.Descriptor instead.
+func (*OddsRequest) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OddsRequest) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *OddsRequest) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *OddsRequest) GetSpecifier() string {
+	if x != nil {
+		return x.Specifier
+	}
+	return ""
+}
+
+func (x *OddsRequest) GetOutcomeId() string {
+	if x != nil {
+		return x.OutcomeId
+	}
+	return ""
+}
+
+func (x *OddsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type BatchOddsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Odds          []*OddsRequest         `protobuf:"bytes,1,rep,name=odds,proto3" json:"odds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOddsRequest) Reset() {
+	*x = BatchOddsRequest{}
+	mi := &file_odds_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOddsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOddsRequest) ProtoMessage() {}
+
+func (x *BatchOddsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOddsRequest.ProtoReflect.Descriptor instead.
+func (*BatchOddsRequest) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchOddsRequest) GetOdds() []*OddsRequest {
+	if x != nil {
+		return x.Odds
+	}
+	return nil
+}
+
+// OddsResult odds of a batch request, odds is not set when the outcome does not exist
+type OddsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *OddsRequest           `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Odds          *OddsDetails           `protobuf:"bytes,2,opt,name=odds,proto3" json:"odds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OddsResult) Reset() {
+	*x = OddsResult{}
+	mi := &file_odds_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OddsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OddsResult) ProtoMessage() {}
+
+func (x *OddsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OddsResult.ProtoReflect.Descriptor instead.
+func (*OddsResult) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OddsResult) GetRequest() *OddsRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *OddsResult) GetOdds() *OddsDetails {
+	if x != nil {
+		return x.Odds
+	}
+	return nil
+}
+
+// BatchOddsReply results in the order of the requests
+type BatchOddsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*OddsResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOddsReply) Reset() {
+	*x = BatchOddsReply{}
+	mi := &file_odds_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOddsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOddsReply) ProtoMessage() {}
+
+func (x *BatchOddsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOddsReply.ProtoReflect.Descriptor instead.
+func (*BatchOddsReply) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchOddsReply) GetResults() []*OddsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type FixtureStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       int64                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FixtureStatusRequest) Reset() {
+	*x = FixtureStatusRequest{}
+	mi := &file_odds_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FixtureStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FixtureStatusRequest) ProtoMessage() {}
+
+func (x *FixtureStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FixtureStatusRequest.ProtoReflect.Descriptor instead.
+func (*FixtureStatusRequest) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FixtureStatusRequest) GetMatchId() int64 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+// SubscribeRequest producer_id 0 sends the markets of the active producer of each match
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchIds      []int64                `protobuf:"varint,1,rep,packed,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+	ProducerId    int64                  `protobuf:"varint,2,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_odds_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_odds_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_odds_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubscribeRequest) GetMatchIds() []int64 {
+	if x != nil {
+		return x.MatchIds
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetProducerId() int64 {
+	if x != nil {
+		return x.ProducerId
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+var File_odds_service_proto protoreflect.FileDescriptor
+
+const file_odds_service_proto_rawDesc = "" +
+	"\n" +
+	"\x12odds_service.proto\x12\x10touchvas.odds.v1\x1a\n" +
+	"odds.proto\"b\n" +
+	"\fMatchRequest\x12\x1f\n" +
+	"\vproducer_id\x18\x01 \x01(\x03R\n" +
+	"producerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\x03R\amatchId\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"\xb8\x01\n" +
+	"\fMatchMarkets\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x03R\amatchId\x12\x1f\n" +
+	"\vproducer_id\x18\x02 \x01(\x03R\n" +
+	"producerId\x122\n" +
+	"\amarkets\x18\x03 \x03(\v2\x18.touchvas.odds.v1.MarketR\amarkets\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x03R\bsequence\"\x9e\x01\n" +
+	"\rMarketRequest\x12\x1f\n" +
+	"\vproducer_id\x18\x01 \x01(\x03R\n" +
+	"producerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\x03R\amatchId\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\x03R\bmarketId\x12\x1c\n" +
+	"\tspecifier\x18\x04 \x01(\tR\tspecifier\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\"\x9a\x01\n" +
+	"\vOddsRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x03R\amatchId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\x03R\bmarketId\x12\x1c\n" +
+	"\tspecifier\x18\x03 \x01(\tR\tspecifier\x12\x1d\n" +
+	"\n" +
+	"outcome_id\x18\x04 \x01(\tR\toutcomeId\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\"E\n" +
+	"\x10BatchOddsRequest\x121\n" +
+	"\x04odds\x18\x01 \x03(\v2\x1d.touchvas.odds.v1.OddsRequestR\x04odds\"x\n" +
+	"\n" +
+	"OddsResult\x127\n" +
+	"\arequest\x18\x01 \x01(\v2\x1d.touchvas.odds.v1.OddsRequestR\arequest\x121\n" +
+	"\x04odds\x18\x02 \x01(\v2\x1d.touchvas.odds.v1.OddsDetailsR\x04odds\"H\n" +
+	"\x0eBatchOddsReply\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.touchvas.odds.v1.OddsResultR\aresults\"1\n" +
+	"\x14FixtureStatusRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x03R\amatchId\"h\n" +
+	"\x10SubscribeRequest\x12\x1b\n" +
+	"\tmatch_ids\x18\x01 \x03(\x03R\bmatchIds\x12\x1f\n" +
+	"\vproducer_id\x18\x02 \x01(\x03R\n" +
+	"producerId\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale2\xf9\x03\n" +
+	"\vOddsService\x12O\n" +
+	"\rGetAllMarkets\x12\x1e.touchvas.odds.v1.MatchRequest\x1a\x1e.touchvas.odds.v1.MatchMarkets\x12F\n" +
+	"\tGetMarket\x12\x1f.touchvas.odds.v1.MarketRequest\x1a\x18.touchvas.odds.v1.Market\x12G\n" +
+	"\aGetOdds\x12\x1d.touchvas.odds.v1.OddsRequest\x1a\x1d.touchvas.odds.v1.OddsDetails\x12T\n" +
+	"\fGetBatchOdds\x12\".touchvas.odds.v1.BatchOddsRequest\x1a .touchvas.odds.v1.BatchOddsReply\x12[\n" +
+	"\x10GetFixtureStatus\x12&.touchvas.odds.v1.FixtureStatusRequest\x1a\x1f.touchvas.odds.v1.FixtureStatus\x12U\n" +
+	"\rSubscribeOdds\x12\".touchvas.odds.v1.SubscribeRequest\x1a\x1e.touchvas.odds.v1.MatchMarkets0\x01B(Z&github.com/touchvas/odds-sdk/v2/oddspbb\x06proto3"
+
+var (
+	file_odds_service_proto_rawDescOnce sync.Once
+	file_odds_service_proto_rawDescData []byte
+)
+
+func file_odds_service_proto_rawDescGZIP() []byte {
+	file_odds_service_proto_rawDescOnce.Do(func() {
+		file_odds_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_odds_service_proto_rawDesc), len(file_odds_service_proto_rawDesc)))
+	})
+	return file_odds_service_proto_rawDescData
+}
+
+var file_odds_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_odds_service_proto_goTypes = []any{
+	(*MatchRequest)(nil),         // 0: touchvas.odds.v1.MatchRequest
+	(*MatchMarkets)(nil),         // 1: touchvas.odds.v1.MatchMarkets
+	(*MarketRequest)(nil),        // 2: touchvas.odds.v1.MarketRequest
+	(*OddsRequest)(nil),          // 3: touchvas.odds.v1.OddsRequest
+	(*BatchOddsRequest)(nil),     // 4: touchvas.odds.v1.BatchOddsRequest
+	(*OddsResult)(nil),           // 5: touchvas.odds.v1.OddsResult
+	(*BatchOddsReply)(nil),       // 6: touchvas.odds.v1.BatchOddsReply
+	(*FixtureStatusRequest)(nil), // 7: touchvas.odds.v1.FixtureStatusRequest
+	(*SubscribeRequest)(nil),     // 8: touchvas.odds.v1.SubscribeRequest
+	(*Market)(nil),               // 9: touchvas.odds.v1.Market
+	(*OddsDetails)(nil),          // 10: touchvas.odds.v1.OddsDetails
+	(*FixtureStatus)(nil),        // 11: touchvas.odds.v1.FixtureStatus
+}
+var file_odds_service_proto_depIdxs = []int32{
+	9,  // 0: touchvas.odds.v1.MatchMarkets.markets:type_name -> touchvas.odds.v1.Market
+	3,  // 1: touchvas.odds.v1.BatchOddsRequest.odds:type_name -> touchvas.odds.v1.OddsRequest
+	3,  // 2: touchvas.odds.v1.OddsResult.request:type_name -> touchvas.odds.v1.OddsRequest
+	10, // 3: touchvas.odds.v1.OddsResult.odds:type_name -> touchvas.odds.v1.OddsDetails
+	5,  // 4: touchvas.odds.v1.BatchOddsReply.results:type_name -> touchvas.odds.v1.OddsResult
+	0,  // 5: touchvas.odds.v1.OddsService.GetAllMarkets:input_type -> touchvas.odds.v1.MatchRequest
+	2,  // 6: touchvas.odds.v1.OddsService.GetMarket:input_type -> touchvas.odds.v1.MarketRequest
+	3,  // 7: touchvas.odds.v1.OddsService.GetOdds:input_type -> touchvas.odds.v1.OddsRequest
+	4,  // 8: touchvas.odds.v1.OddsService.GetBatchOdds:input_type -> touchvas.odds.v1.BatchOddsRequest
+	7,  // 9: touchvas.odds.v1.OddsService.GetFixtureStatus:input_type -> touchvas.odds.v1.FixtureStatusRequest
+	8,  // 10: touchvas.odds.v1.OddsService.SubscribeOdds:input_type -> touchvas.odds.v1.SubscribeRequest
+	1,  // 11: touchvas.odds.v1.OddsService.GetAllMarkets:output_type -> touchvas.odds.v1.MatchMarkets
+	9,  // 12: touchvas.odds.v1.OddsService.GetMarket:output_type -> touchvas.odds.v1.Market
+	10, // 13: touchvas.odds.v1.OddsService.GetOdds:output_type -> touchvas.odds.v1.OddsDetails
+	6,  // 14: touchvas.odds.v1.OddsService.GetBatchOdds:output_type -> touchvas.odds.v1.BatchOddsReply
+	11, // 15: touchvas.odds.v1.OddsService.GetFixtureStatus:output_type -> touchvas.odds.v1.FixtureStatus
+	1,  // 16: touchvas.odds.v1.OddsService.SubscribeOdds:output_type -> touchvas.odds.v1.MatchMarkets
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_odds_service_proto_init() }
+func file_odds_service_proto_init() {
+	if File_odds_service_proto != nil {
+		return
+	}
+	file_odds_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_odds_service_proto_rawDesc), len(file_odds_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_odds_service_proto_goTypes,
+		DependencyIndexes: file_odds_service_proto_depIdxs,
+		MessageInfos:      file_odds_service_proto_msgTypes,
+	}.Build()
+	File_odds_service_proto = out.File
+	file_odds_service_proto_goTypes = nil
+	file_odds_service_proto_depIdxs = nil
+}
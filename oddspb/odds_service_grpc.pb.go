@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: odds_service.proto
+
+package oddspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OddsService_GetAllMarkets_FullMethodName    = "/touchvas.odds.v1.OddsService/GetAllMarkets"
+	OddsService_GetMarket_FullMethodName        = "/touchvas.odds.v1.OddsService/GetMarket"
+	OddsService_GetOdds_FullMethodName          = "/touchvas.odds.v1.OddsService/GetOdds"
+	OddsService_GetBatchOdds_FullMethodName     = "/touchvas.odds.v1.OddsService/GetBatchOdds"
+	OddsService_GetFixtureStatus_FullMethodName = "/touchvas.odds.v1.OddsService/GetFixtureStatus"
+	OddsService_SubscribeOdds_FullMethodName    = "/touchvas.odds.v1.OddsService/SubscribeOdds"
+)
+
+// OddsServiceClient is the client API for OddsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OddsService read API of a feed, see the grpcserver package
+type OddsServiceClient interface {
+	// GetAllMarkets gets all markets of a match
+	GetAllMarkets(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchMarkets, error)
+	// GetMarket gets a market of a match, NOT_FOUND when the market does not exist
+	GetMarket(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*Market, error)
+	// GetOdds gets the odds of an outcome, NOT_FOUND when the outcome does not exist
+	GetOdds(ctx context.Context, in *OddsRequest, opts ...grpc.CallOption) (*OddsDetails, error)
+	// GetBatchOdds gets the odds of several outcomes, e.g the selections of a betslip
+	GetBatchOdds(ctx context.Context, in *BatchOddsRequest, opts ...grpc.CallOption) (*BatchOddsReply, error)
+	// GetFixtureStatus gets the status and score of a match
+	GetFixtureStatus(ctx context.Context, in *FixtureStatusRequest, opts ...grpc.CallOption) (*FixtureStatus, error)
+	// SubscribeOdds sends all markets of the matches and then all markets of a match every time its odds change
+	SubscribeOdds(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchMarkets], error)
+}
+
+type oddsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOddsServiceClient(cc grpc.ClientConnInterface) OddsServiceClient {
+	return &oddsServiceClient{cc}
+}
+
+func (c *oddsServiceClient) GetAllMarkets(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchMarkets, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchMarkets)
+	err := c.cc.Invoke(ctx, OddsService_GetAllMarkets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oddsServiceClient) GetMarket(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*Market, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Market)
+	err := c.cc.Invoke(ctx, OddsService_GetMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oddsServiceClient) GetOdds(ctx context.Context, in *OddsRequest, opts ...grpc.CallOption) (*OddsDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OddsDetails)
+	err := c.cc.Invoke(ctx, OddsService_GetOdds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oddsServiceClient) GetBatchOdds(ctx context.Context, in *BatchOddsRequest, opts ...grpc.CallOption) (*BatchOddsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchOddsReply)
+	err := c.cc.Invoke(ctx, OddsService_GetBatchOdds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oddsServiceClient) GetFixtureStatus(ctx context.Context, in *FixtureStatusRequest, opts ...grpc.CallOption) (*FixtureStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FixtureStatus)
+	err := c.cc.Invoke(ctx, OddsService_GetFixtureStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oddsServiceClient) SubscribeOdds(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchMarkets], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OddsService_ServiceDesc.Streams[0], OddsService_SubscribeOdds_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, MatchMarkets]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OddsService_SubscribeOddsClient = grpc.ServerStreamingClient[MatchMarkets]
+
+// OddsServiceServer is the server API for OddsService service.
+// All implementations must embed UnimplementedOddsServiceServer
+// for forward compatibility.
+//
+// OddsService read API of a feed, see the grpcserver package
+type OddsServiceServer interface {
+	// GetAllMarkets gets all markets of a match
+	GetAllMarkets(context.Context, *MatchRequest) (*MatchMarkets, error)
+	// GetMarket gets a market of a match, NOT_FOUND when the market does not exist
+	GetMarket(context.Context, *MarketRequest) (*Market, error)
+	// GetOdds gets the odds of an outcome, NOT_FOUND when the outcome does not exist
+	GetOdds(context.Context, *OddsRequest) (*OddsDetails, error)
+	// GetBatchOdds gets the odds of several outcomes, e.g the selections of a betslip
+	GetBatchOdds(context.Context, *BatchOddsRequest) (*BatchOddsReply, error)
+	// GetFixtureStatus gets the status and score of a match
+	GetFixtureStatus(context.Context, *FixtureStatusRequest) (*FixtureStatus, error)
+	// SubscribeOdds sends all markets of the matches and then all markets of a match every time its odds change
+	SubscribeOdds(*SubscribeRequest, grpc.ServerStreamingServer[MatchMarkets]) error
+	mustEmbedUnimplementedOddsServiceServer()
+}
+
+// UnimplementedOddsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOddsServiceServer struct{}
+
+func (UnimplementedOddsServiceServer) GetAllMarkets(context.Context, *MatchRequest) (*MatchMarkets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllMarkets not implemented")
+}
+func (UnimplementedOddsServiceServer) GetMarket(context.Context, *MarketRequest) (*Market, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarket not implemented")
+}
+func (UnimplementedOddsServiceServer) GetOdds(context.Context, *OddsRequest) (*OddsDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOdds not implemented")
+}
+func (UnimplementedOddsServiceServer) GetBatchOdds(context.Context, *BatchOddsRequest) (*BatchOddsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchOdds not implemented")
+}
+func (UnimplementedOddsServiceServer) GetFixtureStatus(context.Context, *FixtureStatusRequest) (*FixtureStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFixtureStatus not implemented")
+}
+func (UnimplementedOddsServiceServer) SubscribeOdds(*SubscribeRequest, grpc.ServerStreamingServer[MatchMarkets]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOdds not implemented")
+}
+func (UnimplementedOddsServiceServer) mustEmbedUnimplementedOddsServiceServer() {}
+func (UnimplementedOddsServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeOddsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OddsServiceServer will
+// result in compilation errors.
+type UnsafeOddsServiceServer interface {
+	mustEmbedUnimplementedOddsServiceServer()
+}
+
+func RegisterOddsServiceServer(s grpc.ServiceRegistrar, srv OddsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOddsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OddsService_ServiceDesc, srv)
+}
+
+func _OddsService_GetAllMarkets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OddsServiceServer).GetAllMarkets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OddsService_GetAllMarkets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OddsServiceServer).GetAllMarkets(ctx, req.(*MatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OddsService_GetMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OddsServiceServer).GetMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OddsService_GetMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OddsServiceServer).GetMarket(ctx, req.(*MarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OddsService_GetOdds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OddsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OddsServiceServer).GetOdds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OddsService_GetOdds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OddsServiceServer).GetOdds(ctx, req.(*OddsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OddsService_GetBatchOdds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOddsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OddsServiceServer).GetBatchOdds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OddsService_GetBatchOdds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OddsServiceServer).GetBatchOdds(ctx, req.(*BatchOddsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OddsService_GetFixtureStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FixtureStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OddsServiceServer).GetFixtureStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OddsService_GetFixtureStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OddsServiceServer).GetFixtureStatus(ctx, req.(*FixtureStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OddsService_SubscribeOdds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OddsServiceServer).SubscribeOdds(m, &grpc.GenericServerStream[SubscribeRequest, MatchMarkets]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OddsService_SubscribeOddsServer = grpc.ServerStreamingServer[MatchMarkets]
+
+// OddsService_ServiceDesc is the grpc.ServiceDesc for OddsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OddsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "touchvas.odds.v1.OddsService",
+	HandlerType: (*OddsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAllMarkets",
+			Handler:    _OddsService_GetAllMarkets_Handler,
+		},
+		{
+			MethodName: "GetMarket",
+			Handler:    _OddsService_GetMarket_Handler,
+		},
+		{
+			MethodName: "GetOdds",
+			Handler:    _OddsService_GetOdds_Handler,
+		},
+		{
+			MethodName: "GetBatchOdds",
+			Handler:    _OddsService_GetBatchOdds_Handler,
+		},
+		{
+			MethodName: "GetFixtureStatus",
+			Handler:    _OddsService_GetFixtureStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeOdds",
+			Handler:       _OddsService_SubscribeOdds_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "odds_service.proto",
+}
@@ -0,0 +1,512 @@
+package overrides
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nats "github.com/nats-io/nats.go"
+	"github.com/touchvas/odds-sdk/v2/changelog"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/keyspace"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+	"log"
+	"sort"
+	"time"
+)
+
+// Kind type of a trader override
+type Kind string
+
+// Suspended suspends a match (MarketID 0) or a market
+const Suspended Kind = "suspend"
+
+// Locked keeps the market as it is, incoming odds changes for the market are ignored
+const Locked Kind = "lock"
+
+// Odds replaces the odds of an outcome
+const Odds Kind = "odds"
+
+// Cleared audit action recorded when overrides are cleared
+const Cleared Kind = "clear"
+
+// MaxAuditEntries number of audit entries kept per match
+const MaxAuditEntries = 1000
+
+// ErrMissingTrader returned when an override is attempted without a trader
+var ErrMissingTrader = errors.New("trader is required")
+
+// ErrNotConfigured returned when overrides are saved on a store without a redis client
+var ErrNotConfigured = errors.New("overrides store is not configured")
+
+// Override trader override of a match, market or outcome
+type Override struct {
+	Kind      Kind    `json:"kind"`
+	MatchID   int64   `json:"match_id"`
+	MarketID  int64   `json:"market_id"`
+	Specifier string  `json:"specifier"`
+	OutcomeID string  `json:"outcome_id,omitempty"`
+	Odds      float64 `json:"odds,omitempty"`
+	Trader    string  `json:"trader"`
+	Reason    string  `json:"reason"`
+	CreatedAt int64   `json:"created_at"`
+}
+
+// AuditEntry record of a trader action
+type AuditEntry struct {
+	Action    Kind    `json:"action"`
+	MatchID   int64   `json:"match_id"`
+	MarketID  int64   `json:"market_id"`
+	Specifier string  `json:"specifier"`
+	OutcomeID string  `json:"outcome_id,omitempty"`
+	Odds      float64 `json:"odds,omitempty"`
+	Trader    string  `json:"trader"`
+	Reason    string  `json:"reason"`
+	Cleared   int64   `json:"cleared,omitempty"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// Store keeps trader overrides per match in redis, a nil Store has no overrides
+type Store struct {
+	RedisClient utils.Redis
+
+	// Keys builds the overrides keys, set the tenant keyspace when tenants share a redis database
+	Keys keyspace.Keyspace
+
+	// NatsClient optional nats connection odds_invalidation is published to after every override change,
+	// caches and subscribers of the match are not notified when not set
+	NatsClient *nats.Conn
+
+	// ctx bounds the redis calls of the store, see WithContext
+	ctx context.Context
+}
+
+// NewStore creates an overrides store backed by the supplied redis client
+func NewStore(client utils.Redis) *Store {
+
+	return &Store{
+		RedisClient: client,
+	}
+}
+
+// WithContext gets a copy of the store whose redis calls are bound by the supplied context
+func (s *Store) WithContext(ctx context.Context) *Store {
+
+	if s == nil {
+
+		return nil
+	}
+
+	store := *s
+	store.ctx = ctx
+	return &store
+}
+
+// context gets the context of the redis calls, background when the store has no context
+func (s *Store) context() context.Context {
+
+	if s == nil || s.ctx == nil {
+
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+// SuspendMatch suspends all markets of the match until the override is cleared
+func (s *Store) SuspendMatch(trader string, matchID int64, reason string) error {
+
+	return s.save(Override{Kind: Suspended, MatchID: matchID, Trader: trader, Reason: reason})
+}
+
+// SuspendMarket suspends the market until the override is cleared
+func (s *Store) SuspendMarket(trader string, matchID, marketID int64, specifier, reason string) error {
+
+	return s.save(Override{Kind: Suspended, MatchID: matchID, MarketID: marketID, Specifier: specifier, Trader: trader, Reason: reason})
+}
+
+// OverrideOdds replaces the odds of the outcome until the override is cleared
+func (s *Store) OverrideOdds(trader string, matchID, marketID int64, specifier, outcomeID string, odds float64, reason string) error {
+
+	if odds <= 1 {
+
+		return fmt.Errorf("invalid odds %.2f, odds must be greater than 1", odds)
+	}
+
+	return s.save(Override{Kind: Odds, MatchID: matchID, MarketID: marketID, Specifier: specifier, OutcomeID: outcomeID, Odds: odds, Trader: trader, Reason: reason})
+}
+
+// LockMarket keeps the market as currently stored, incoming odds changes for the market are ignored until the override is cleared
+func (s *Store) LockMarket(trader string, matchID, marketID int64, specifier, reason string) error {
+
+	return s.save(Override{Kind: Locked, MatchID: matchID, MarketID: marketID, Specifier: specifier, Trader: trader, Reason: reason})
+}
+
+// ClearOverride clears all overrides of the market and its outcomes, a marketID of 0 clears all overrides of the match
+func (s *Store) ClearOverride(trader string, matchID, marketID int64, specifier, reason string) error {
+
+	if len(trader) == 0 {
+
+		return ErrMissingTrader
+	}
+
+	if s == nil || s.RedisClient == nil {
+
+		return ErrNotConfigured
+	}
+
+	var fields []string
+
+	for _, o := range s.GetOverrides(matchID) {
+
+		if marketID == 0 || (o.MarketID == marketID && o.Specifier == specifier) {
+
+			fields = append(fields, field(o))
+		}
+	}
+
+	err := utils.DeleteRedisHashFields(s.context(), s.RedisClient, s.Keys.Overrides(matchID), fields...)
+	if err != nil {
+
+		return err
+	}
+
+	s.incrementSequence(matchID, marketID, specifier)
+
+	s.audit(AuditEntry{
+		Action:    Cleared,
+		MatchID:   matchID,
+		MarketID:  marketID,
+		Specifier: specifier,
+		Trader:    trader,
+		Reason:    reason,
+		Cleared:   int64(len(fields)),
+	})
+
+	return nil
+}
+
+// GetOverrides gets all active overrides of the match
+func (s *Store) GetOverrides(matchID int64) []Override {
+
+	if s == nil || s.RedisClient == nil {
+
+		return nil
+	}
+
+	data, _ := utils.GetRedisHash(s.context(), s.RedisClient, s.Keys.Overrides(matchID))
+
+	var overrides []Override
+
+	for k, v := range data {
+
+		var o Override
+
+		err := json.Unmarshal([]byte(v), &o)
+		if err != nil {
+
+			log.Printf("failed to unmarshal override %s %s ", k, err.Error())
+			continue
+		}
+
+		overrides = append(overrides, o)
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+
+		return overrides[i].CreatedAt < overrides[j].CreatedAt
+	})
+
+	return overrides
+}
+
+// GetAuditTrail gets the trader actions of the match, most recent first
+func (s *Store) GetAuditTrail(matchID int64) []AuditEntry {
+
+	if s == nil || s.RedisClient == nil {
+
+		return nil
+	}
+
+	data, _ := utils.GetRedisList(s.context(), s.RedisClient, s.Keys.OverridesAudit(matchID), 0, -1)
+
+	var entries []AuditEntry
+
+	for _, v := range data {
+
+		var e AuditEntry
+
+		err := json.Unmarshal([]byte(v), &e)
+		if err != nil {
+
+			log.Printf("failed to unmarshal override audit entry %s ", err.Error())
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+// FilterLocked removes locked markets from the incoming markets of the match so that stored locked markets are left unchanged
+func (s *Store) FilterLocked(matchID int64, markets []models.Market) []models.Market {
+
+	overrides := s.GetOverrides(matchID)
+	if len(overrides) == 0 {
+
+		return markets
+	}
+
+	locked := make(map[string]bool)
+
+	for _, o := range overrides {
+
+		if o.Kind == Locked {
+
+			locked[marketKey(o.MarketID, o.Specifier)] = true
+		}
+	}
+
+	if len(locked) == 0 {
+
+		return markets
+	}
+
+	var filtered []models.Market
+
+	for _, m := range markets {
+
+		if locked[marketKey(m.MarketID, m.Specifier)] {
+
+			continue
+		}
+
+		filtered = append(filtered, m)
+	}
+
+	return filtered
+}
+
+// ApplyMarkets applies suspend and odds overrides of the match to a copy of the supplied markets
+func (s *Store) ApplyMarkets(matchID int64, markets []models.Market) []models.Market {
+
+	if markets == nil {
+
+		return nil
+	}
+
+	overrides := s.GetOverrides(matchID)
+	if len(overrides) == 0 {
+
+		return markets
+	}
+
+	applied := make([]models.Market, len(markets))
+
+	for i, m := range markets {
+
+		applied[i] = apply(overrides, m)
+	}
+
+	return applied
+}
+
+// ApplyMarket applies suspend and odds overrides of the match to a copy of the supplied market
+func (s *Store) ApplyMarket(matchID int64, market *models.Market) *models.Market {
+
+	if market == nil {
+
+		return nil
+	}
+
+	overrides := s.GetOverrides(matchID)
+	if len(overrides) == 0 {
+
+		return market
+	}
+
+	applied := apply(overrides, *market)
+	return &applied
+}
+
+// ApplyOdds applies suspend and odds overrides to a copy of the supplied odds
+func (s *Store) ApplyOdds(odds *models.OddsDetails) *models.OddsDetails {
+
+	if odds == nil {
+
+		return nil
+	}
+
+	overrides := s.GetOverrides(odds.MatchID)
+	if len(overrides) == 0 {
+
+		return odds
+	}
+
+	market := apply(overrides, models.Market{
+		MarketID:   odds.MarketID,
+		Specifier:  odds.Specifier,
+		Status:     odds.Status,
+		StatusName: odds.StatusName,
+		Outcomes: []models.Outcome{
+			{
+				OutcomeID: odds.OutcomeID,
+				Odds:      odds.Odds,
+				Active:    odds.Active,
+			},
+		},
+	})
+
+	applied := *odds
+	applied.Status = market.Status
+	applied.StatusName = market.StatusName
+	applied.Odds = market.Outcomes[0].Odds
+
+	return &applied
+}
+
+func (s *Store) save(o Override) error {
+
+	if len(o.Trader) == 0 {
+
+		return ErrMissingTrader
+	}
+
+	if s == nil || s.RedisClient == nil {
+
+		return ErrNotConfigured
+	}
+
+	o.CreatedAt = time.Now().UnixMilli()
+
+	js, _ := json.Marshal(o)
+
+	err := utils.SetRedisHashField(s.context(), s.RedisClient, s.Keys.Overrides(o.MatchID), field(o), string(js))
+	if err != nil {
+
+		return err
+	}
+
+	s.incrementSequence(o.MatchID, o.MarketID, o.Specifier)
+
+	s.audit(AuditEntry{
+		Action:    o.Kind,
+		MatchID:   o.MatchID,
+		MarketID:  o.MarketID,
+		Specifier: o.Specifier,
+		OutcomeID: o.OutcomeID,
+		Odds:      o.Odds,
+		Trader:    o.Trader,
+		Reason:    o.Reason,
+		Timestamp: o.CreatedAt,
+	})
+
+	return nil
+}
+
+// incrementSequence increments the sequence of the match, logs the change and publishes it to odds_invalidation,
+// served markets of the match changed. marketID 0 changes all markets of the match
+func (s *Store) incrementSequence(matchID, marketID int64, specifier string) {
+
+	sequence, err := utils.IncrRedisSequence(s.context(), s.RedisClient, s.Keys.MatchVersion(matchID), constants.MatchVersionExpiry)
+	if err != nil {
+
+		log.Printf("error incrementing sequence of match %d | %s", matchID, err.Error())
+		return
+	}
+
+	change := changelog.Entry{Sequence: sequence, AllMarkets: marketID == 0}
+	if marketID > 0 {
+
+		change.Markets = []string{changelog.MarketKey(marketID, specifier)}
+	}
+
+	changelog.Push(s.context(), s.RedisClient, s.Keys, matchID, change)
+
+	if s.NatsClient == nil {
+
+		return
+	}
+
+	// overrides apply to the markets of all producers
+	utils.PublishToNats(s.NatsClient, constants.OddsInvalidationTopic, models.OddsInvalidation{
+		MatchID:   matchID,
+		Sequence:  sequence,
+		Timestamp: time.Now().UnixMilli(),
+	})
+}
+
+func (s *Store) audit(entry AuditEntry) {
+
+	if entry.Timestamp == 0 {
+
+		entry.Timestamp = time.Now().UnixMilli()
+	}
+
+	js, _ := json.Marshal(entry)
+
+	err := utils.PushRedisList(s.context(), s.RedisClient, s.Keys.OverridesAudit(entry.MatchID), string(js), MaxAuditEntries)
+	if err != nil {
+
+		log.Printf("error saving override audit entry %s | %s", string(js), err.Error())
+	}
+}
+
+// apply applies suspend and odds overrides to a copy of the market
+func apply(overrides []Override, market models.Market) models.Market {
+
+	key := marketKey(market.MarketID, market.Specifier)
+
+	copied := false
+
+	for _, o := range overrides {
+
+		switch o.Kind {
+
+		case Suspended:
+			if o.MarketID == 0 || marketKey(o.MarketID, o.Specifier) == key {
+
+				market.Status = constants.SuspendedMarketStatus
+				market.StatusName = constants.SuspendedMarketStatusName
+			}
+
+		case Odds:
+			if marketKey(o.MarketID, o.Specifier) != key {
+
+				continue
+			}
+
+			// copy outcomes before the first change so the supplied market is not modified
+			if !copied {
+
+				market.Outcomes = append([]models.Outcome(nil), market.Outcomes...)
+				copied = true
+			}
+
+			for i, outcome := range market.Outcomes {
+
+				if outcome.OutcomeID == o.OutcomeID {
+
+					outcome.Odds = o.Odds
+					outcome.FormattedOdds = ""
+					market.Outcomes[i] = outcome
+				}
+			}
+
+		}
+	}
+
+	return market
+}
+
+func field(o Override) string {
+
+	return fmt.Sprintf("%s|%s|%s", o.Kind, marketKey(o.MarketID, o.Specifier), o.OutcomeID)
+}
+
+func marketKey(marketID int64, specifier string) string {
+
+	return fmt.Sprintf("%d:%s", marketID, specifier)
+}
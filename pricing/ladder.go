@@ -0,0 +1,70 @@
+package pricing
+
+import (
+	"math"
+	"sort"
+)
+
+// MinimumOdds lowest odds ever returned by the pricing layer
+const MinimumOdds = 1.01
+
+// ladderSteps increments in cents used to build DefaultLadder, each increment applies up to the upper bound
+var ladderSteps = []struct {
+	upper     int64
+	increment int64
+}{
+	{200, 1},
+	{300, 2},
+	{400, 5},
+	{600, 10},
+	{1000, 20},
+	{2000, 50},
+	{3000, 100},
+	{5000, 200},
+	{10000, 500},
+	{100000, 1000},
+}
+
+// DefaultLadder standard decimal odds ladder from 1.01 to 1000
+var DefaultLadder = buildLadder()
+
+func buildLadder() []float64 {
+
+	var ladder []float64
+
+	// work in cents to avoid accumulating float errors
+	price := int64(101)
+
+	for _, step := range ladderSteps {
+
+		for ; price < step.upper; price += step.increment {
+
+			ladder = append(ladder, float64(price)/100)
+		}
+	}
+
+	return append(ladder, float64(price)/100)
+}
+
+// roundDown rounds odds down to the nearest step in the supplied ascending ladder, odds are never rounded up
+// so that rounding does not reduce the applied margin. odds above the ladder are capped at the top step
+func roundDown(odds float64, ladder []float64) float64 {
+
+	if len(ladder) == 0 {
+
+		return math.Floor(odds*100) / 100
+	}
+
+	// index of the first ladder step greater than odds
+	i := sort.Search(len(ladder), func(i int) bool {
+
+		return ladder[i] > odds+1e-9
+	})
+
+	if i == 0 {
+
+		return ladder[0]
+	}
+
+	return ladder[i-1]
+}
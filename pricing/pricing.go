@@ -0,0 +1,376 @@
+package pricing
+
+import (
+	"math"
+	"sync"
+
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/oddsformat"
+)
+
+// Rule margin configuration for a brand, sport and market.
+// empty Brand and zero SportID or MarketID match all brands, sports or markets
+type Rule struct {
+
+	// Brand white-label brand the rule applies to
+	Brand string `json:"brand"`
+
+	// SportID sport the rule applies to
+	SportID int64 `json:"sport_id"`
+
+	// MarketID market the rule applies to
+	MarketID int64 `json:"market_id"`
+
+	// Margin overround applied on top of the fair probabilities e.g 0.05 for a 105% book
+	Margin float64 `json:"margin"`
+
+	// MinOdds odds lower than MinOdds are raised to MinOdds, 0 for no cap
+	MinOdds float64 `json:"min_odds"`
+
+	// MaxOdds odds higher than MaxOdds are lowered to MaxOdds, 0 for no cap
+	MaxOdds float64 `json:"max_odds"`
+}
+
+// PricedFeed applies brand margins to odds read from the wrapped feed.
+// only reads are repriced, odds changes are passed through so the stored feed remains untouched
+type PricedFeed struct {
+	feeds.Feed
+
+	// Brand brand whose rules are applied
+	Brand string
+
+	// Ladder ascending odds ladder repriced odds are rounded down to
+	Ladder []float64
+
+	// OddsFormat when set formatted_odds are recomputed from the repriced odds
+	OddsFormat oddsformat.Format
+
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// NewPricedFeed creates a feed that reprices odds read from feed using the supplied brand rules
+func NewPricedFeed(feed feeds.Feed, brand string, rules []Rule) *PricedFeed {
+
+	return &PricedFeed{
+		Feed:       feed,
+		Brand:      brand,
+		Ladder:     DefaultLadder,
+		OddsFormat: oddsformat.FormatFromEnv(),
+		rules:      rules,
+	}
+}
+
+// SetRules replaces the pricing rules, safe to call while the feed is being read
+func (p *PricedFeed) SetRules(rules []Rule) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rules = rules
+}
+
+// Rules gets the current pricing rules
+func (p *PricedFeed) Rules() []Rule {
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]Rule(nil), p.rules...)
+}
+
+// ConfigVersion gets a hash of the brand, ladder, odds format and rules combined with the configuration version of the wrapped feed
+func (p *PricedFeed) ConfigVersion() string {
+
+	return feeds.ConfigHash(p.Feed, struct {
+		Brand      string
+		Ladder     []float64
+		OddsFormat oddsformat.Format
+		Rules      []Rule
+	}{p.Brand, p.Ladder, p.OddsFormat, p.Rules()})
+}
+
+// GetAllMarkets gets all markets for a particular matchID with brand margins applied
+func (p *PricedFeed) GetAllMarkets(producerID, matchID int64) []models.Market {
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), p.Feed.GetAllMarkets(producerID, matchID))
+}
+
+// GetAllMarketsWithSequence gets all markets for a particular matchID with brand margins applied and the sequence of the match
+func (p *PricedFeed) GetAllMarketsWithSequence(producerID, matchID int64) ([]models.Market, int64) {
+
+	markets, sequence := p.Feed.GetAllMarketsWithSequence(producerID, matchID)
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), markets), sequence
+}
+
+// GetMarketsIfChanged gets all markets for a particular matchID with brand margins applied if the match changed since sinceSequence
+func (p *PricedFeed) GetMarketsIfChanged(producerID, matchID, sinceSequence int64) ([]models.Market, int64, bool) {
+
+	markets, sequence, changed := p.Feed.GetMarketsIfChanged(producerID, matchID, sinceSequence)
+	if !changed {
+
+		return nil, sequence, false
+	}
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), markets), sequence, true
+}
+
+// GetChangesSince gets the markets of a particular matchID changed after sinceSequence with brand margins applied
+func (p *PricedFeed) GetChangesSince(producerID, matchID, sinceSequence int64) models.MatchChanges {
+
+	changes := p.Feed.GetChangesSince(producerID, matchID, sinceSequence)
+	changes.Markets = p.PriceMarkets(p.Feed.GetSportID(matchID), changes.Markets)
+	return changes
+}
+
+// GetMarket gets market for a particular matchID and marketID with brand margins applied
+func (p *PricedFeed) GetMarket(producerID, matchID, marketID int64, specifier string) *models.Market {
+
+	return p.priceMarket(p.Feed.GetSportID(matchID), p.Feed.GetMarket(producerID, matchID, marketID, specifier))
+}
+
+// GetOdds gets odds for the supplied outcome with brand margins applied, the whole market is repriced
+// since the margin is distributed across all outcomes of the market
+func (p *PricedFeed) GetOdds(matchID, marketID int64, specifier, outcomeID string) *models.OddsDetails {
+
+	return p.priceOdds(p.Feed.GetOdds(matchID, marketID, specifier, outcomeID))
+}
+
+// GetAllMarketsOrderByList gets all markets order by the supplied list with brand margins applied
+func (p *PricedFeed) GetAllMarketsOrderByList(producerID, matchID int64, marketOderList []models.MarketOrderList) []models.Market {
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), p.Feed.GetAllMarketsOrderByList(producerID, matchID, marketOderList))
+}
+
+// GetSpecifiedMarkets gets the specified markets with brand margins applied
+func (p *PricedFeed) GetSpecifiedMarkets(producerID, matchID int64, marketList []models.MarketOrderList) []models.Market {
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), p.Feed.GetSpecifiedMarkets(producerID, matchID, marketList))
+}
+
+// GetAllMarketsByLocale gets all translated markets with brand margins applied
+func (p *PricedFeed) GetAllMarketsByLocale(producerID, matchID int64, locale string) []models.Market {
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), p.Feed.GetAllMarketsByLocale(producerID, matchID, locale))
+}
+
+// GetMarketByLocale gets translated market with brand margins applied
+func (p *PricedFeed) GetMarketByLocale(producerID, matchID, marketID int64, specifier, locale string) *models.Market {
+
+	return p.priceMarket(p.Feed.GetSportID(matchID), p.Feed.GetMarketByLocale(producerID, matchID, marketID, specifier, locale))
+}
+
+// GetOddsByLocale gets translated odds with brand margins applied
+func (p *PricedFeed) GetOddsByLocale(matchID, marketID int64, specifier, outcomeID, locale string) *models.OddsDetails {
+
+	return p.priceOdds(p.Feed.GetOddsByLocale(matchID, marketID, specifier, outcomeID, locale))
+}
+
+// GetAllMarketsOrderByListByLocale gets all translated markets order by the supplied list with brand margins applied
+func (p *PricedFeed) GetAllMarketsOrderByListByLocale(producerID, matchID int64, marketOderList []models.MarketOrderList, locale string) []models.Market {
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), p.Feed.GetAllMarketsOrderByListByLocale(producerID, matchID, marketOderList, locale))
+}
+
+// GetSpecifiedMarketsByLocale gets the specified translated markets with brand margins applied
+func (p *PricedFeed) GetSpecifiedMarketsByLocale(producerID, matchID int64, marketList []models.MarketOrderList, locale string) []models.Market {
+
+	return p.PriceMarkets(p.Feed.GetSportID(matchID), p.Feed.GetSpecifiedMarketsByLocale(producerID, matchID, marketList, locale))
+}
+
+// PriceMarkets applies brand margins to a copy of the supplied markets
+func (p *PricedFeed) PriceMarkets(sportID int64, markets []models.Market) []models.Market {
+
+	if markets == nil {
+
+		return nil
+	}
+
+	priced := make([]models.Market, len(markets))
+
+	for i, m := range markets {
+
+		priced[i] = *p.priceMarket(sportID, &m)
+	}
+
+	return priced
+}
+
+func (p *PricedFeed) priceMarket(sportID int64, market *models.Market) *models.Market {
+
+	if market == nil {
+
+		return nil
+	}
+
+	priced := *market
+	priced.Outcomes = Reprice(market.Outcomes, p.rule(sportID, market.MarketID), p.Ladder)
+
+	return oddsformat.FormatMarket(&priced, p.OddsFormat)
+}
+
+func (p *PricedFeed) priceOdds(odds *models.OddsDetails) *models.OddsDetails {
+
+	if odds == nil {
+
+		return nil
+	}
+
+	rule := p.rule(odds.SportID, odds.MarketID)
+
+	priced := *odds
+	priced.FormattedOdds = ""
+
+	// the odds as read are bounded without the margin when the market of the producer the odds were read from
+	// is gone or no longer has the outcome
+	if priced.Odds > 0 {
+
+		priced.Odds = limit(priced.Odds, rule, p.Ladder)
+	}
+
+	market := p.Feed.GetMarket(odds.ProducerID, odds.MatchID, odds.MarketID, odds.Specifier)
+	if market == nil {
+
+		return oddsformat.FormatOddsDetails(&priced, p.OddsFormat)
+	}
+
+	for _, o := range Reprice(market.Outcomes, rule, p.Ladder) {
+
+		if o.OutcomeID == odds.OutcomeID && o.Odds > 0 {
+
+			priced.Odds = o.Odds
+			priced.Probability = o.Probability
+		}
+	}
+
+	return oddsformat.FormatOddsDetails(&priced, p.OddsFormat)
+}
+
+// rule gets the most specific rule matching the brand, sport and market, a market match is more specific than a sport match
+func (p *PricedFeed) rule(sportID, marketID int64) *Rule {
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var matched *Rule
+	best := -1
+
+	for i, r := range p.rules {
+
+		if (len(r.Brand) > 0 && r.Brand != p.Brand) || (r.SportID > 0 && r.SportID != sportID) || (r.MarketID > 0 && r.MarketID != marketID) {
+
+			continue
+		}
+
+		score := 0
+
+		if len(r.Brand) > 0 {
+
+			score++
+		}
+
+		if r.SportID > 0 {
+
+			score += 2
+		}
+
+		if r.MarketID > 0 {
+
+			score += 4
+		}
+
+		if score > best {
+
+			rule := p.rules[i]
+			matched = &rule
+			best = score
+		}
+	}
+
+	return matched
+}
+
+// Reprice recomputes odds for the supplied outcomes of one market using the supplied rule.
+// fair probabilities are taken from Outcome.Probability when supplied for all priced outcomes,
+// otherwise they are derived by removing the margin from the odds. Outcomes without odds are left unchanged.
+// a nil rule only enforces the minimum odds
+func Reprice(outcomes []models.Outcome, rule *Rule, ladder []float64) []models.Outcome {
+
+	if outcomes == nil {
+
+		return nil
+	}
+
+	repriced := make([]models.Outcome, len(outcomes))
+	copy(repriced, outcomes)
+
+	useProbability := true
+	bookPercentage := 0.0
+	probabilityTotal := 0.0
+
+	for _, o := range outcomes {
+
+		if o.Odds <= 0 {
+
+			continue
+		}
+
+		if o.Probability <= 0 {
+
+			useProbability = false
+		}
+
+		bookPercentage += 1 / o.Odds
+		probabilityTotal += o.Probability
+	}
+
+	for i, o := range repriced {
+
+		if o.Odds <= 0 {
+
+			continue
+		}
+
+		o.FormattedOdds = ""
+
+		if rule != nil {
+
+			fair := (1 / o.Odds) / bookPercentage
+
+			if useProbability && probabilityTotal > 0 {
+
+				fair = o.Probability / probabilityTotal
+			}
+
+			o.Odds = 1 / (fair * (1 + rule.Margin))
+			o.Probability = fair
+		}
+
+		o.Odds = limit(o.Odds, rule, ladder)
+		repriced[i] = o
+	}
+
+	return repriced
+}
+
+// limit rounds the odds down to the ladder and applies the caps of the rule, a nil rule only enforces the minimum odds
+func limit(odds float64, rule *Rule, ladder []float64) float64 {
+
+	if rule != nil {
+
+		odds = roundDown(odds, ladder)
+
+		if rule.MinOdds > 0 && odds < rule.MinOdds {
+
+			odds = rule.MinOdds
+		}
+
+		if rule.MaxOdds > 0 && odds > rule.MaxOdds {
+
+			odds = rule.MaxOdds
+		}
+	}
+
+	return math.Max(odds, MinimumOdds)
+}
@@ -0,0 +1,237 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+)
+
+// Producers producers whose markets are exported, 1 for live and 3 for prematch markets
+var Producers = []int64{1, 3}
+
+// Record stored state of a match for one producer, one record is written per line of a snapshot
+type Record struct {
+
+	// MatchID match the record belongs to
+	MatchID int64 `json:"match_id"`
+
+	// ProducerID producer the markets are stored under
+	ProducerID int64 `json:"producer_id"`
+
+	// ActiveProducerID active producer of the match
+	ActiveProducerID int64 `json:"active_producer_id"`
+
+	// SportID sport of the match
+	SportID int64 `json:"sport_id"`
+
+	// Markets stored markets of the match
+	Markets []models.Market `json:"markets"`
+
+	// FixtureStatus stored fixture status of the match, nil when the match has no fixture status
+	FixtureStatus *models.FixtureStatus `json:"fixture_status,omitempty"`
+}
+
+// Read reads the stored state of the supplied matches from feed, all matches are read when no matchID is supplied
+func Read(feed feeds.Feed, matchIDs ...int64) []Record {
+
+	var records []Record
+
+	for _, producerID := range Producers {
+
+		ids := matchIDs
+		if len(ids) == 0 {
+
+			ids = feed.GetMatchIDs(producerID)
+		}
+
+		for _, matchID := range ids {
+
+			if record := ReadMatch(feed, producerID, matchID); record != nil {
+
+				records = append(records, *record)
+			}
+		}
+	}
+
+	return records
+}
+
+// ReadMatch reads the stored state of a match for the supplied producer, returns nil if the match has no stored markets
+func ReadMatch(feed feeds.Feed, producerID, matchID int64) *Record {
+
+	markets := feed.GetStoredMarkets(producerID, matchID)
+	if len(markets) == 0 {
+
+		return nil
+	}
+
+	activeProducerID, _ := feed.GetProducerID(matchID)
+
+	record := &Record{
+		MatchID:          matchID,
+		ProducerID:       producerID,
+		ActiveProducerID: activeProducerID,
+		SportID:          feed.GetSportID(matchID),
+		Markets:          markets,
+	}
+
+	if fx, ok := feed.GetStoredFixtureStatus(matchID); ok {
+
+		record.FixtureStatus = &fx
+	}
+
+	return record
+}
+
+// Write writes the record to feed, the stored markets are replaced with the markets of the record followed by
+// the active producer and the fixture status when the record has one
+func Write(feed feeds.Feed, record Record) error {
+
+	err := feed.SetStoredMarkets(record.ProducerID, record.MatchID, record.SportID, record.Markets)
+	if err != nil {
+
+		return fmt.Errorf("error writing markets of match %d producer %d | %s", record.MatchID, record.ProducerID, err.Error())
+	}
+
+	if record.ActiveProducerID > 0 {
+
+		err = feed.SetProducerID(record.MatchID, record.ActiveProducerID)
+		if err != nil {
+
+			return fmt.Errorf("error writing producer of match %d | %s", record.MatchID, err.Error())
+		}
+	}
+
+	if record.FixtureStatus == nil {
+
+		return nil
+	}
+
+	err = feed.SetFixtureStatus(record.MatchID, *record.FixtureStatus)
+	if err != nil {
+
+		return fmt.Errorf("error writing fixture status of match %d | %s", record.MatchID, err.Error())
+	}
+
+	return nil
+}
+
+// Export writes the stored state of the supplied matches as JSON lines to w, all matches are exported when no matchID is supplied.
+// Returns the number of records written
+func Export(feed feeds.Feed, w io.Writer, matchIDs ...int64) (int, error) {
+
+	encoder := json.NewEncoder(w)
+
+	total := 0
+
+	for _, record := range Read(feed, matchIDs...) {
+
+		err := encoder.Encode(record)
+		if err != nil {
+
+			return total, err
+		}
+
+		total++
+	}
+
+	return total, nil
+}
+
+// Import writes JSON lines records read from r to feed, records that fail to write are logged and skipped.
+// Returns the number of records written
+func Import(feed feeds.Feed, r io.Reader) (int, error) {
+
+	total := 0
+
+	err := Scan(r, func(record Record) error {
+
+		err := Write(feed, record)
+		if err != nil {
+
+			log.Printf("%s", err.Error())
+			return nil
+		}
+
+		total++
+		return nil
+	})
+
+	return total, err
+}
+
+// Scan calls fn with every record of a snapshot, stops at the first invalid record or error returned by fn
+func Scan(r io.Reader, fn func(record Record) error) error {
+
+	decoder := json.NewDecoder(bufio.NewReader(r))
+
+	for n := 1; ; n++ {
+
+		var record Record
+
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+
+			return nil
+		}
+
+		if err != nil {
+
+			return fmt.Errorf("error reading snapshot record %d | %s", n, err.Error())
+		}
+
+		err = fn(record)
+		if err != nil {
+
+			return err
+		}
+	}
+}
+
+// ExportFile exports the stored state of the supplied matches to a JSON lines file
+func ExportFile(feed feeds.Feed, path string, matchIDs ...int64) (int, error) {
+
+	file, err := os.Create(path)
+	if err != nil {
+
+		return 0, err
+	}
+
+	w := bufio.NewWriter(file)
+
+	total, err := Export(feed, w, matchIDs...)
+	if err != nil {
+
+		file.Close()
+		return total, err
+	}
+
+	err = w.Flush()
+	if err != nil {
+
+		file.Close()
+		return total, err
+	}
+
+	return total, file.Close()
+}
+
+// ImportFile imports a JSON lines snapshot file into feed
+func ImportFile(feed feeds.Feed, path string) (int, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+
+		return 0, err
+	}
+
+	defer file.Close()
+
+	return Import(feed, file)
+}
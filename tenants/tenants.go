@@ -0,0 +1,224 @@
+package tenants
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/feeds/mysqlfeeds"
+	"github.com/touchvas/odds-sdk/v2/feeds/redisfeed"
+	"github.com/touchvas/odds-sdk/v2/utils"
+)
+
+// ErrUnknownTenant returned when a feed is requested for a tenant that was not added
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// Tenant feed settings of an operator, empty settings default to the environment settings of the default instance
+type Tenant struct {
+
+	// ID identifies the tenant
+	ID string `json:"id"`
+
+	// Backend feed backend, redis (default) or mysql
+	Backend string `json:"backend"`
+
+	// Namespace feed namespace of the tenant, defaults to ODDS_FEED_NAMESPACE
+	Namespace string `json:"namespace"`
+
+	// KeyPrefix prefix of all redis keys of the tenant, required when tenants share a redis database
+	KeyPrefix string `json:"key_prefix"`
+
+	// RedisDB redis database number of the tenant, 0 keeps the database of the environment.
+	// Not supported by a redis cluster where tenants are separated by KeyPrefix
+	RedisDB int `json:"redis_db"`
+
+	// Database MySQL database name of the tenant, mysql backend only
+	Database string `json:"database"`
+}
+
+// Manager holds the feeds of several tenants in one process, redis clients and the nats connection are shared between tenants
+type Manager struct {
+
+	// NatsClient nats connection shared by all tenant feeds
+	NatsClient *nats.Conn
+
+	mu           sync.RWMutex
+	feeds        map[string]feeds.Feed
+	redisClients map[int]utils.Redis
+}
+
+// NewManager creates a tenant manager, the shared nats connection is made with FEEDS_SERVICE_NATS_URI
+func NewManager() *Manager {
+
+	return &Manager{
+		NatsClient:   utils.GetNatsConnection(),
+		feeds:        make(map[string]feeds.Feed),
+		redisClients: make(map[int]utils.Redis),
+	}
+}
+
+// Add adds the feed of the tenant, an existing feed of the tenant is replaced.
+// Use it to add feeds wrapped with the tenant pricing or filters
+func (m *Manager) Add(tenantID string, feed feeds.Feed) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.feeds[tenantID] = feed
+}
+
+// AddTenant creates and adds the feed of the tenant, settings the tenant does not set are taken from the environment
+func (m *Manager) AddTenant(tenant Tenant) (feeds.Feed, error) {
+
+	if len(tenant.ID) == 0 {
+
+		return nil, fmt.Errorf("tenant id is required")
+	}
+
+	redisConfig := utils.RedisConfigFromEnv()
+
+	if tenant.RedisDB > 0 {
+
+		redisConfig.DB = tenant.RedisDB
+	}
+
+	var feed feeds.Feed
+
+	switch tenant.Backend {
+
+	case "redis", "":
+		cfg := redisfeed.ConfigFromEnv()
+
+		if len(tenant.Namespace) > 0 {
+
+			cfg.Namespace = tenant.Namespace
+		}
+
+		cfg.KeyPrefix = tenant.KeyPrefix
+		cfg.Redis = redisConfig
+		cfg.RedisClient = m.redisClient(redisConfig)
+		cfg.NatsClient = m.NatsClient
+
+		feed = redisfeed.New(cfg)
+
+	case "mysql":
+		cfg := mysqlfeeds.ConfigFromEnv()
+
+		if len(tenant.Namespace) > 0 {
+
+			cfg.Namespace = tenant.Namespace
+		}
+
+		if len(tenant.Database) > 0 {
+
+			cfg.Database.Name = tenant.Database
+		}
+
+		cfg.KeyPrefix = tenant.KeyPrefix
+		cfg.Redis = redisConfig
+		cfg.RedisClient = m.redisClient(redisConfig)
+		cfg.NatsClient = m.NatsClient
+
+		feed = mysqlfeeds.New(cfg)
+
+	default:
+		return nil, fmt.Errorf("tenant %s: unknown backend %s", tenant.ID, tenant.Backend)
+
+	}
+
+	m.Add(tenant.ID, feed)
+
+	return feed, nil
+}
+
+// LoadFile adds the tenants listed in a JSON file containing an array of tenants
+func (m *Manager) LoadFile(path string) error {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+
+		return err
+	}
+
+	var tenants []Tenant
+
+	err = json.Unmarshal(data, &tenants)
+	if err != nil {
+
+		return fmt.Errorf("invalid tenants file %s | %s", path, err.Error())
+	}
+
+	for _, t := range tenants {
+
+		_, err = m.AddTenant(t)
+		if err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get gets the feed of the tenant
+func (m *Manager) Get(tenantID string) (feeds.Feed, error) {
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	feed, ok := m.feeds[tenantID]
+	if !ok {
+
+		return nil, fmt.Errorf("%w %s", ErrUnknownTenant, tenantID)
+	}
+
+	return feed, nil
+}
+
+// Remove removes the feed of the tenant
+func (m *Manager) Remove(tenantID string) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.feeds, tenantID)
+}
+
+// Tenants gets the IDs of all tenants
+func (m *Manager) Tenants() []string {
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ids []string
+
+	for id := range m.feeds {
+
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// redisClient gets the shared redis client of the database
+func (m *Manager) redisClient(cfg utils.RedisConfig) utils.Redis {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	client, ok := m.redisClients[cfg.DB]
+	if !ok {
+
+		client = utils.NewRedisClient(cfg)
+		m.redisClients[cfg.DB] = client
+	}
+
+	return client
+}
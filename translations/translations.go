@@ -0,0 +1,254 @@
+package translations
+
+import (
+	"context"
+	"fmt"
+	"github.com/touchvas/odds-sdk/v2/keyspace"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+	"log"
+	"strings"
+)
+
+// Store keeps translated market and outcome names keyed by marketID, outcomeID and locale
+type Store struct {
+	RedisClient utils.Redis
+
+	// Keys builds the translation keys, set the tenant keyspace when tenants share a redis database
+	Keys keyspace.Keyspace
+
+	// ctx bounds the redis calls of the store, see WithContext
+	ctx context.Context
+}
+
+// NewStore creates a translations store backed by the supplied redis client
+func NewStore(client utils.Redis) *Store {
+
+	return &Store{
+		RedisClient: client,
+	}
+}
+
+// WithContext gets a copy of the store whose redis calls are bound by the supplied context
+func (s *Store) WithContext(ctx context.Context) *Store {
+
+	if s == nil {
+
+		return nil
+	}
+
+	store := *s
+	store.ctx = ctx
+	return &store
+}
+
+// context gets the context of the redis calls, background when the store has no context
+func (s *Store) context() context.Context {
+
+	if s == nil || s.ctx == nil {
+
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+// SetMarketName saves the market name for the supplied locale
+func (s *Store) SetMarketName(marketID int64, locale, name string) error {
+
+	redisKey := s.Keys.MarketTranslation(marketID, normalizeLocale(locale))
+	return s.changed(utils.SetRedisKey(s.context(), s.RedisClient, redisKey, name))
+
+}
+
+// SetOutcomeName saves the outcome name for the supplied locale
+func (s *Store) SetOutcomeName(marketID int64, outcomeID, locale, name string) error {
+
+	redisKey := s.Keys.OutcomeTranslation(marketID, outcomeID, normalizeLocale(locale))
+	return s.changed(utils.SetRedisKey(s.context(), s.RedisClient, redisKey, name))
+
+}
+
+// DeleteMarketName deletes the market name saved for the supplied locale
+func (s *Store) DeleteMarketName(marketID int64, locale string) error {
+
+	redisKey := s.Keys.MarketTranslation(marketID, normalizeLocale(locale))
+	return s.changed(utils.DeleteRedisKey(s.context(), s.RedisClient, redisKey))
+
+}
+
+// DeleteOutcomeName deletes the outcome name saved for the supplied locale
+func (s *Store) DeleteOutcomeName(marketID int64, outcomeID, locale string) error {
+
+	redisKey := s.Keys.OutcomeTranslation(marketID, outcomeID, normalizeLocale(locale))
+	return s.changed(utils.DeleteRedisKey(s.context(), s.RedisClient, redisKey))
+
+}
+
+// Version gets the version of the translations, the version changes with every translation saved or deleted
+func (s *Store) Version() string {
+
+	version, _ := utils.GetRedisKey(s.context(), s.RedisClient, s.Keys.TranslationsVersion())
+	return version
+}
+
+// changed increments the version of the translations after a successful change
+func (s *Store) changed(err error) error {
+
+	if err != nil {
+
+		return err
+	}
+
+	_, err = utils.IncrRedisSequence(s.context(), s.RedisClient, s.Keys.TranslationsVersion(), 0)
+	return err
+}
+
+// GetMarketName gets the market name for the supplied locale, returns an empty string if there is no translation
+func (s *Store) GetMarketName(marketID int64, locale string) string {
+
+	redisKey := s.Keys.MarketTranslation(marketID, normalizeLocale(locale))
+	name, _ := utils.GetRedisKey(s.context(), s.RedisClient, redisKey)
+	return name
+
+}
+
+// GetOutcomeName gets the outcome name for the supplied locale, returns an empty string if there is no translation
+func (s *Store) GetOutcomeName(marketID int64, outcomeID, locale string) string {
+
+	redisKey := s.Keys.OutcomeTranslation(marketID, outcomeID, normalizeLocale(locale))
+	name, _ := utils.GetRedisKey(s.context(), s.RedisClient, redisKey)
+	return name
+
+}
+
+// TranslateMarkets translates market and outcome names of the supplied markets to the supplied locale.
+// names without a translation fallback to the stored name
+func (s *Store) TranslateMarkets(markets []models.Market, locale string) []models.Market {
+
+	if len(markets) == 0 || len(normalizeLocale(locale)) == 0 {
+
+		return markets
+	}
+
+	locale = normalizeLocale(locale)
+
+	// fetch all translations for the supplied markets in one round trip
+	var keys []string
+
+	for _, m := range markets {
+
+		keys = append(keys, s.Keys.MarketTranslation(m.MarketID, locale))
+
+		for _, o := range m.Outcomes {
+
+			keys = append(keys, s.Keys.OutcomeTranslation(m.MarketID, o.OutcomeID, locale))
+		}
+	}
+
+	names, err := utils.GetRedisKeys(s.context(), s.RedisClient, keys...)
+	if err != nil {
+
+		log.Printf("error getting %s translations %s ", locale, err.Error())
+		return markets
+	}
+
+	translated := make([]models.Market, len(markets))
+
+	x := 0
+
+	for i, m := range markets {
+
+		m.MarketName = render(names[x], m.MarketName, m.Specifier)
+		x++
+
+		outcomes := make([]models.Outcome, len(m.Outcomes))
+
+		for j, o := range m.Outcomes {
+
+			o.OutcomeName = render(names[x], o.OutcomeName, m.Specifier)
+			outcomes[j] = o
+			x++
+		}
+
+		if m.Outcomes != nil {
+
+			m.Outcomes = outcomes
+		}
+
+		translated[i] = m
+	}
+
+	return translated
+}
+
+// TranslateMarket translates market and outcome names of the supplied market to the supplied locale
+func (s *Store) TranslateMarket(market *models.Market, locale string) *models.Market {
+
+	if market == nil {
+
+		return nil
+	}
+
+	translated := s.TranslateMarkets([]models.Market{*market}, locale)
+	return &translated[0]
+}
+
+// TranslateOddsDetails translates market and outcome names of the supplied odds to the supplied locale
+func (s *Store) TranslateOddsDetails(odds *models.OddsDetails, locale string) *models.OddsDetails {
+
+	if odds == nil || len(normalizeLocale(locale)) == 0 {
+
+		return odds
+	}
+
+	locale = normalizeLocale(locale)
+
+	names, err := utils.GetRedisKeys(s.context(), s.RedisClient,
+		s.Keys.MarketTranslation(odds.MarketID, locale),
+		s.Keys.OutcomeTranslation(odds.MarketID, odds.OutcomeID, locale))
+	if err != nil {
+
+		log.Printf("error getting %s translations %s ", locale, err.Error())
+		return odds
+	}
+
+	translated := *odds
+	translated.MarketName = render(names[0], odds.MarketName, odds.Specifier)
+	translated.OutcomeName = render(names[1], odds.OutcomeName, odds.Specifier)
+
+	return &translated
+}
+
+// render replaces {name} placeholders in the translated template with values from the market specifier
+// e.g. "Jumla {total}" with specifier "total=2.5" becomes "Jumla 2.5", returns fallback if there is no translation
+func render(template, fallback, specifier string) string {
+
+	if len(template) == 0 {
+
+		return fallback
+	}
+
+	if !strings.Contains(template, "{") || len(specifier) == 0 {
+
+		return template
+	}
+
+	for _, part := range strings.Split(specifier, "|") {
+
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+
+			continue
+		}
+
+		template = strings.ReplaceAll(template, fmt.Sprintf("{%s}", kv[0]), kv[1])
+	}
+
+	return template
+}
+
+func normalizeLocale(locale string) string {
+
+	return strings.ToLower(strings.TrimSpace(locale))
+}
@@ -10,9 +10,8 @@ import (
 
 func PublishToNats(nc *nats.Conn, natsTopic string, payload interface{}) error {
 
-	servicePrefix := os.Getenv("FEEDS_SERVICE_QUEUE_PREFIX")
 	payloadByte, _ := json.Marshal(payload)
-	queueName := fmt.Sprintf("%s.%s", servicePrefix, natsTopic)
+	queueName := NatsSubject(natsTopic)
 
 	err := nc.Publish(queueName, payloadByte)
 	if err != nil {
@@ -23,6 +22,12 @@ func PublishToNats(nc *nats.Conn, natsTopic string, payload interface{}) error {
 	return err
 }
 
+// NatsSubject gets the nats subject of the topic, FEEDS_SERVICE_QUEUE_PREFIX.topic
+func NatsSubject(natsTopic string) string {
+
+	return fmt.Sprintf("%s.%s", os.Getenv("FEEDS_SERVICE_QUEUE_PREFIX"), natsTopic)
+}
+
 // GetNatsConnection gets nats connection connection
 func GetNatsConnection() *nats.Conn {
 
@@ -1,61 +1,177 @@
 package utils
 
 import (
+	"context"
 	"fmt"
-	"github.com/go-redis/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/touchvas/odds-sdk/v2/keyspace"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// RedisStandalone single redis server
+const RedisStandalone = "standalone"
+
+// RedisSentinel redis master monitored by sentinels, the client follows failovers
+const RedisSentinel = "sentinel"
+
+// RedisCluster redis cluster
+const RedisCluster = "cluster"
+
+// RedisConfig connection settings of a redis client
+type RedisConfig struct {
+
+	// Mode standalone (default), sentinel or cluster
+	Mode string
+
+	// Host and Port of a standalone server
+	Host string
+	Port int64
+
+	// Addrs host:port addresses of the sentinels or of the cluster seed nodes
+	Addrs []string
+
+	// MasterName name of the master monitored by the sentinels
+	MasterName string
+
+	// DB database number, not supported in cluster mode
+	DB       int
+	Password string
+
+	// HashTags stores all keys of a match in one cluster slot, see keyspace.Keyspace.WithHashTags
+	HashTags bool
+}
+
+// RedisConfigFromEnv gets redis connection settings from FEEDS_REDIS_HOST, FEEDS_REDIS_PORT, FEEDS_REDIS_DATABASE_NUMBER and FEEDS_REDIS_PASSWORD,
+// sentinel and cluster clients are set with FEEDS_REDIS_MODE, FEEDS_REDIS_ADDRS and FEEDS_REDIS_MASTER_NAME.
+// FEEDS_REDIS_HASH_TAGS defaults to true in cluster mode
+func RedisConfigFromEnv() RedisConfig {
+
+	mode := strings.ToLower(os.Getenv("FEEDS_REDIS_MODE"))
+
+	hashTags, err := strconv.ParseBool(os.Getenv("FEEDS_REDIS_HASH_TAGS"))
+	if err != nil {
+
+		hashTags = mode == RedisCluster
+	}
+
+	portNumber, _ := strconv.ParseInt(os.Getenv("FEEDS_REDIS_PORT"), 10, 64)
+
+	dbNumber, _ := strconv.ParseInt(os.Getenv("FEEDS_REDIS_DATABASE_NUMBER"), 10, 64)
+
+	var addrs []string
+
+	for _, addr := range strings.Split(os.Getenv("FEEDS_REDIS_ADDRS"), ",") {
+
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return RedisConfig{
+		Mode:       mode,
+		Host:       os.Getenv("FEEDS_REDIS_HOST"),
+		Port:       portNumber,
+		Addrs:      addrs,
+		MasterName: os.Getenv("FEEDS_REDIS_MASTER_NAME"),
+		DB:         int(dbNumber),
+		Password:   os.Getenv("FEEDS_REDIS_PASSWORD"),
+		HashTags:   hashTags,
+	}
+}
+
+// IsCluster checks if the settings are of a redis cluster
+func (cfg RedisConfig) IsCluster() bool {
+
+	return cfg.Mode == RedisCluster
+}
+
 // RedisClient gets redis client
-func RedisClient() *redis.Client {
+func RedisClient() Redis {
+
+	return NewRedisClient(RedisConfigFromEnv())
+}
 
-	host := os.Getenv("FEEDS_REDIS_HOST")
-	if len(host) == 0 {
+// NewRedisClient gets redis client for the supplied settings, a failover client in sentinel mode and a cluster client in cluster mode
+func NewRedisClient(cfg RedisConfig) Redis {
+
+	switch cfg.Mode {
+
+	case RedisSentinel:
+		if len(cfg.Addrs) == 0 || len(cfg.MasterName) == 0 {
+
+			panic("missing odds redis sentinel addresses or master name")
+		}
+
+		return NewRedis(redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    cfg.MasterName,
+			SentinelAddrs: cfg.Addrs,
+			Password:      cfg.Password,
+			DB:            cfg.DB,
+			MinIdleConns:  10,
+			PoolSize:      10000,
+			ReadTimeout:   3 * time.Second,
+		}))
+
+	case RedisCluster:
+		if len(cfg.Addrs) == 0 {
+
+			panic("missing odds redis cluster addresses")
+		}
+
+		return NewRedis(redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Addrs,
+			Password:     cfg.Password,
+			MinIdleConns: 10,
+			PoolSize:     10000,
+			ReadTimeout:  3 * time.Second,
+		}))
+
+	}
+
+	if len(cfg.Host) == 0 {
 
 		panic("missing odds redis host")
 	}
 
-	portNumber, _ := strconv.ParseInt(os.Getenv("FEEDS_REDIS_PORT"), 10, 64)
+	portNumber := cfg.Port
 
 	if portNumber == 0 {
 
 		portNumber = 6379
 	}
 
-	dbNumber, _ := strconv.ParseInt(os.Getenv("FEEDS_REDIS_DATABASE_NUMBER"), 10, 64)
-
-	auth := os.Getenv("FEEDS_REDIS_PASSWORD")
-
-	uri := fmt.Sprintf("redis://%s:%d", host, portNumber)
-	uri = fmt.Sprintf("%s:%d", host, portNumber)
+	uri := fmt.Sprintf("%s:%d", cfg.Host, portNumber)
 
 	opts := redis.Options{
 		MinIdleConns: 10,
 		//IdleTimeout:  60 * time.Second,
 		PoolSize:    10000,
 		Addr:        uri,
-		DB:          int(dbNumber), // use default DB
+		DB:          cfg.DB,
 		ReadTimeout: 3 * time.Second,
 	}
 
-	if len(auth) > 0 {
+	if len(cfg.Password) > 0 {
 
-		opts.Password = auth
+		opts.Password = cfg.Password
 	}
 
 	client := redis.NewClient(&opts)
 
-	return client
+	return NewRedis(client)
 }
 
 // GetRedisKey get saved key from redis
-func GetRedisKey(conn *redis.Client, key string) (string, error) {
+func GetRedisKey(ctx context.Context, conn Redis, key string) (string, error) {
 
 	var data string
-	data, err := conn.Get(getKey(key)).Result()
+	data, err := conn.Get(ctx, getKey(key))
 	if err != nil {
 
 		//return data, fmt.Errorf("error getting key %s: %v", key, err)
@@ -67,9 +183,9 @@ func GetRedisKey(conn *redis.Client, key string) (string, error) {
 }
 
 // SetRedisKeyWithExpiry saves key to redis with TTL value
-func SetRedisKeyWithExpiry(conn *redis.Client, key string, value string, seconds int) error {
+func SetRedisKeyWithExpiry(ctx context.Context, conn Redis, key string, value string, seconds int) error {
 
-	_, err := conn.Set(getKey(key), value, time.Second*time.Duration(seconds)).Result()
+	err := conn.Set(ctx, getKey(key), value, time.Second*time.Duration(seconds))
 	if err != nil {
 
 		v := string(value)
@@ -87,9 +203,9 @@ func SetRedisKeyWithExpiry(conn *redis.Client, key string, value string, seconds
 }
 
 // SetRedisKey saves key to redis without expiry
-func SetRedisKey(conn *redis.Client, key string, value string) error {
+func SetRedisKey(ctx context.Context, conn Redis, key string, value string) error {
 
-	_, err := conn.Set(getKey(key), value, 0).Result()
+	err := conn.Set(ctx, getKey(key), value, 0)
 	if err != nil {
 
 		v := string(value)
@@ -106,10 +222,44 @@ func SetRedisKey(conn *redis.Client, key string, value string) error {
 	return err
 }
 
+// IncrRedisKey increments the counter saved in key and renews its TTL, 0 seconds for no expiry
+func IncrRedisKey(ctx context.Context, conn Redis, key string, seconds int) (int64, error) {
+
+	value, err := conn.Incr(ctx, getKey(key), 0, time.Second*time.Duration(seconds))
+	if err != nil {
+
+		log.Printf("error incrementing redisKey %s error %s", key, err.Error())
+		return 0, fmt.Errorf("error incrementing key %s | %s", key, err)
+	}
+
+	return value, nil
+}
+
+// IncrRedisSequence increments the sequence counter saved in key and renews its TTL, 0 seconds for no expiry.
+// A sequence that does not exist starts from SequenceSeed so it never repeats after it expired or was deleted
+func IncrRedisSequence(ctx context.Context, conn Redis, key string, seconds int) (int64, error) {
+
+	value, err := conn.Incr(ctx, getKey(key), SequenceSeed(), time.Second*time.Duration(seconds))
+	if err != nil {
+
+		log.Printf("error incrementing sequence %s error %s", key, err.Error())
+		return 0, fmt.Errorf("error incrementing sequence %s | %s", key, err)
+	}
+
+	return value, nil
+}
+
+// SequenceSeed gets the value a new sequence starts from, the current unix time in milliseconds.
+// A sequence gets fewer than one change per millisecond on average so a new sequence starts above any value it had before
+func SequenceSeed() int64 {
+
+	return time.Now().UnixMilli()
+}
+
 // DeleteRedisKey deletes a saved redis keys
-func DeleteRedisKey(conn *redis.Client, key string) error {
+func DeleteRedisKey(ctx context.Context, conn Redis, key string) error {
 
-	_, err := conn.Del(getKey(key)).Result()
+	_, err := conn.Del(ctx, getKey(key))
 	if err != nil {
 
 		log.Printf("error deleting redisKey %s error %s", key, err.Error())
@@ -119,46 +269,312 @@ func DeleteRedisKey(conn *redis.Client, key string) error {
 	return err
 }
 
-// DeleteKeysByPattern deletes a set of keys matching the supplied pattern
-func DeleteKeysByPattern(conn *redis.Client, keyPattern string) error {
+// DeleteRedisKeys deletes saved redis keys and returns the number of keys that existed
+func DeleteRedisKeys(ctx context.Context, conn Redis, keys ...string) (int64, error) {
 
-	iter := conn.Scan(0, getKey(keyPattern), 0).Iterator()
-	for iter.Next() {
+	if len(keys) == 0 {
 
-		DeleteRedisKey(conn, iter.Val())
+		return 0, nil
 	}
 
-	if err := iter.Err(); err != nil {
+	var prefixedKeys []string
+
+	for _, key := range keys {
+
+		prefixedKeys = append(prefixedKeys, getKey(key))
+	}
+
+	deleted, err := conn.Del(ctx, prefixedKeys...)
+	if err != nil {
+
+		log.Printf("error deleting redisKeys %s error %s", strings.Join(keys, ","), err.Error())
+		return deleted, fmt.Errorf("error deleting keys %s | %s", strings.Join(keys, ","), err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteKeysByPattern deletes a set of keys matching the supplied pattern
+func DeleteKeysByPattern(ctx context.Context, conn Redis, keyPattern string) error {
+
+	_, err := CountDeleteKeysByPattern(ctx, conn, keyPattern)
+	return err
+}
+
+// CountDeleteKeysByPattern deletes a set of keys matching the supplied pattern and returns the number of keys deleted,
+// all masters are scanned on a cluster
+func CountDeleteKeysByPattern(ctx context.Context, conn Redis, keyPattern string) (int64, error) {
+
+	var mu sync.Mutex
+
+	deleted := int64(0)
+
+	err := conn.Scan(ctx, getKey(keyPattern), func(key string) error {
+
+		// scanned keys are already prefixed
+		count, err := conn.Del(ctx, key)
+		if err != nil {
+
+			log.Printf("error deleting redisKey %s error %s", key, err.Error())
+			return nil
+		}
+
+		mu.Lock()
+		deleted += count
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
 
 		log.Printf("error iteration error deleteing keys %s | %s", keyPattern, err.Error())
-		return err
+		return deleted, err
 	}
 
-	return nil
+	return deleted, nil
 }
 
-func RedisKeyExists(conn *redis.Client, key string) (bool, error) {
+// ScanRedisKeys gets all keys matching the supplied pattern, returned keys do not include FEEDS_REDIS_KEY_PREFIX.
+// All masters are scanned on a cluster
+func ScanRedisKeys(ctx context.Context, conn Redis, keyPattern string) ([]string, error) {
+
+	var mu sync.Mutex
 
-	check, err := conn.Exists(getKey(key)).Result()
+	var keys []string
+
+	err := conn.Scan(ctx, getKey(keyPattern), func(key string) error {
+
+		mu.Lock()
+		keys = append(keys, keyspace.Strip(key))
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+
+		log.Printf("error scanning keys %s | %s", keyPattern, err.Error())
+		return keys, err
+	}
+
+	return keys, nil
+}
+
+func RedisKeyExists(ctx context.Context, conn Redis, key string) (bool, error) {
+
+	check, err := conn.Exists(ctx, getKey(key))
 	if err != nil {
 
 		log.Printf("error saving redisKey %s error %s", key, err.Error())
 		return false, err
 	}
 
-	return check > 0, nil
+	return check, nil
+}
+
+// SetRedisHashField saves a field of a redis hash
+func SetRedisHashField(ctx context.Context, conn Redis, key, field, value string) error {
+
+	err := conn.HSet(ctx, getKey(key), field, value)
+	if err != nil {
+
+		log.Printf("error saving redis hash %s field %s error %s", key, field, err.Error())
+		return fmt.Errorf("error setting hash %s field %s: %v", key, field, err)
+	}
+
+	return nil
+}
+
+// GetRedisHash gets all fields of a redis hash, returns an empty map if the hash does not exist
+func GetRedisHash(ctx context.Context, conn Redis, key string) (map[string]string, error) {
+
+	data, err := conn.HGetAll(ctx, getKey(key))
+	if err != nil {
+
+		log.Printf("error getting redis hash %s error %s", key, err.Error())
+		return map[string]string{}, err
+	}
+
+	return data, nil
+}
+
+// DeleteRedisHashFields deletes fields of a redis hash
+func DeleteRedisHashFields(ctx context.Context, conn Redis, key string, fields ...string) error {
+
+	if len(fields) == 0 {
+
+		return nil
+	}
+
+	err := conn.HDel(ctx, getKey(key), fields...)
+	if err != nil {
+
+		log.Printf("error deleting redis hash %s fields error %s", key, err.Error())
+		return fmt.Errorf("error deleting hash %s fields | %s", key, err)
+	}
+
+	return nil
 }
 
+// PushRedisList adds value to the head of a redis list and trims the list to maxLength entries, 0 for no limit
+func PushRedisList(ctx context.Context, conn Redis, key, value string, maxLength int64) error {
+
+	err := conn.LPush(ctx, getKey(key), value, maxLength)
+	if err != nil {
+
+		log.Printf("error pushing to redis list %s error %s", key, err.Error())
+		return fmt.Errorf("error pushing to list %s | %s", key, err)
+	}
+
+	return nil
+}
+
+// PushRedisListWithExpiry adds value to the head of a redis list capped at maxLength entries and renews the TTL of the list
+func PushRedisListWithExpiry(ctx context.Context, conn Redis, key, value string, maxLength int64, seconds int) error {
+
+	err := PushRedisList(ctx, conn, key, value, maxLength)
+	if err != nil {
+
+		return err
+	}
+
+	err = conn.Expire(ctx, getKey(key), time.Second*time.Duration(seconds))
+	if err != nil {
+
+		log.Printf("error setting expiry of redis list %s error %s", key, err.Error())
+		return fmt.Errorf("error setting expiry of list %s | %s", key, err)
+	}
+
+	return nil
+}
+
+// GetRedisList gets entries of a redis list between start and stop inclusive, -1 for the last entry
+func GetRedisList(ctx context.Context, conn Redis, key string, start, stop int64) ([]string, error) {
+
+	data, err := conn.LRange(ctx, getKey(key), start, stop)
+	if err != nil {
+
+		log.Printf("error getting redis list %s error %s", key, err.Error())
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// AddRedisStream appends an entry to a redis stream capped at approximately maxLength entries, 0 for no limit
+func AddRedisStream(ctx context.Context, conn Redis, stream string, values map[string]interface{}, maxLength int64) error {
+
+	err := conn.XAdd(ctx, getKey(stream), values, maxLength)
+	if err != nil {
+
+		log.Printf("error adding to redis stream %s error %s", stream, err.Error())
+		return fmt.Errorf("error adding to stream %s | %s", stream, err)
+	}
+
+	return nil
+}
+
+// getKey applies FEEDS_REDIS_KEY_PREFIX to the key, see keyspace.Key
 func getKey(key string) string {
 
-	if len(os.Getenv("FEEDS_REDIS_KEY_PREFIX")) > 0 {
+	return keyspace.Key(key)
+}
+
+// GetRedisKeys gets multiple saved keys from redis in one round trip, missing keys are returned as empty strings
+func GetRedisKeys(ctx context.Context, conn Redis, keys ...string) ([]string, error) {
+
+	if len(keys) == 0 {
+
+		return []string{}, nil
+	}
+
+	var prefixedKeys []string
+
+	for _, key := range keys {
+
+		prefixedKeys = append(prefixedKeys, getKey(key))
+	}
+
+	values, err := conn.MGet(ctx, prefixedKeys...)
+	if err != nil {
+
+		log.Printf("error getting redisKeys %s error %s", strings.Join(keys, ","), err.Error())
+		return values, err
+	}
+
+	return values, nil
+}
+
+// SetRedisKeys saves keys to redis without expiry in one transaction, keys must be in one slot on a cluster e.g by sharing a hash tag
+func SetRedisKeys(ctx context.Context, conn Redis, values map[string]string) error {
+
+	if len(values) == 0 {
+
+		return nil
+	}
+
+	prefixedValues := make(map[string]string, len(values))
+
+	for key, value := range values {
+
+		prefixedValues[getKey(key)] = value
+	}
+
+	err := conn.SetMany(ctx, prefixedValues)
+	if err != nil {
+
+		log.Printf("error saving %d redisKeys error %s", len(values), err.Error())
+		return fmt.Errorf("error setting %d keys | %s", len(values), err)
+	}
+
+	return nil
+}
+
+// SetRedisKeysWithSequence saves keys to redis without expiry and increments the sequence counter in one transaction,
+// readers never see the keys without the sequence they were saved with. A sequence that does not exist starts from SequenceSeed.
+// Returns the incremented sequence
+func SetRedisKeysWithSequence(ctx context.Context, conn Redis, values map[string]string, sequenceKey string, seconds int) (int64, error) {
+
+	prefixedValues := make(map[string]string, len(values))
 
-		return fmt.Sprintf("%s:%s", os.Getenv("FEEDS_REDIS_KEY_PREFIX"), key) // do this to prevent having very long keys
-		// return goutils.MD5S(fmt.Sprintf("%s:%s", os.Getenv("REDIS_KEY_PREFIX"), key)) // do this to prevent having very long keys
+	for key, value := range values {
 
+		prefixedValues[getKey(key)] = value
 	}
 
-	return key
-	// return goutils.MD5S(key) // do this to prevent having very long keys
+	sequence, err := conn.SetManyIncr(ctx, nil, prefixedValues, getKey(sequenceKey), SequenceSeed(), time.Second*time.Duration(seconds))
+	if err != nil {
+
+		log.Printf("error saving %d redisKeys with sequence %s error %s", len(values), sequenceKey, err.Error())
+		return 0, fmt.Errorf("error setting %d keys with sequence %s | %s", len(values), sequenceKey, err)
+	}
+
+	return sequence, nil
+}
+
+// ReplaceRedisKeysWithSequence deletes the keys of deletes, saves keys to redis without expiry and increments the sequence
+// counter in one transaction, readers never see the keys deleted without the keys that replace them. Returns the incremented sequence
+func ReplaceRedisKeysWithSequence(ctx context.Context, conn Redis, deletes []string, values map[string]string, sequenceKey string, seconds int) (int64, error) {
+
+	prefixedDeletes := make([]string, 0, len(deletes))
+
+	for _, key := range deletes {
+
+		prefixedDeletes = append(prefixedDeletes, getKey(key))
+	}
+
+	prefixedValues := make(map[string]string, len(values))
+
+	for key, value := range values {
+
+		prefixedValues[getKey(key)] = value
+	}
+
+	sequence, err := conn.SetManyIncr(ctx, prefixedDeletes, prefixedValues, getKey(sequenceKey), SequenceSeed(), time.Second*time.Duration(seconds))
+	if err != nil {
+
+		log.Printf("error replacing %d redisKeys with sequence %s error %s", len(deletes), sequenceKey, err.Error())
+		return 0, fmt.Errorf("error replacing %d keys with sequence %s | %s", len(deletes), sequenceKey, err)
+	}
 
+	return sequence, nil
 }
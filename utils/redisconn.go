@@ -0,0 +1,348 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNil returned by Redis.Get when the key does not exist
+var ErrNil = redis.Nil
+
+// Redis redis commands used by the SDK, keys are used as supplied. Every call is bound by the supplied context
+// so deadlines of the caller e.g a grpc request bound the redis round trips
+type Redis interface {
+
+	// Get gets the value of the key, returns ErrNil if the key does not exist
+	Get(ctx context.Context, key string) (string, error)
+
+	// MGet gets the values of the keys, missing keys are returned as empty strings
+	MGet(ctx context.Context, keys ...string) ([]string, error)
+
+	// Set saves the value of the key, 0 expiry for no expiry
+	Set(ctx context.Context, key, value string, expiry time.Duration) error
+
+	// SetMany saves the values of the keys without expiry in one transaction
+	SetMany(ctx context.Context, values map[string]string) error
+
+	// SetManyIncr deletes the keys of deletes, saves the values of the keys without expiry and increments the counter
+	// in one transaction, returns the incremented counter. A counter that does not exist starts from seed,
+	// the expiry of the counter is renewed when not 0
+	SetManyIncr(ctx context.Context, deletes []string, values map[string]string, counter string, seed int64, expiry time.Duration) (int64, error)
+
+	// Incr increments the counter of the key and returns the incremented value. A counter that does not exist starts from seed,
+	// the expiry is renewed when not 0
+	Incr(ctx context.Context, key string, seed int64, expiry time.Duration) (int64, error)
+
+	// Del deletes the keys and returns the number of keys that existed
+	Del(ctx context.Context, keys ...string) (int64, error)
+
+	// Exists checks if the key exists
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Scan calls fn with every key matching the pattern, all masters are scanned on a cluster.
+	// fn may be called concurrently for keys of different masters
+	Scan(ctx context.Context, pattern string, fn func(key string) error) error
+
+	// HSet saves a field of a hash
+	HSet(ctx context.Context, key, field, value string) error
+
+	// HGetAll gets all fields of a hash
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+
+	// HDel deletes fields of a hash
+	HDel(ctx context.Context, key string, fields ...string) error
+
+	// LPush adds value to the head of a list and trims the list to maxLength entries, 0 for no limit
+	LPush(ctx context.Context, key, value string, maxLength int64) error
+
+	// Expire sets the expiry of the key
+	Expire(ctx context.Context, key string, expiry time.Duration) error
+
+	// LRange gets entries of a list between start and stop inclusive
+	LRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+
+	// XAdd appends an entry to a stream capped at approximately maxLength entries, 0 for no limit
+	XAdd(ctx context.Context, stream string, values map[string]interface{}, maxLength int64) error
+
+	// Close closes the connections of the client
+	Close() error
+}
+
+// goRedis Redis backed by a go-redis standalone, failover or cluster client
+type goRedis struct {
+	client redis.UniversalClient
+}
+
+// NewRedis wraps a go-redis client, use it to share a client created by the service
+func NewRedis(client redis.UniversalClient) Redis {
+
+	return &goRedis{client: client}
+}
+
+func (r *goRedis) Get(ctx context.Context, key string) (string, error) {
+
+	return r.client.Get(ctx, key).Result()
+}
+
+func (r *goRedis) MGet(ctx context.Context, keys ...string) ([]string, error) {
+
+	values := make([]string, len(keys))
+
+	// keys of a cluster may be in different slots, get them one by one in a pipeline
+	if r.isCluster() {
+
+		pipe := r.client.Pipeline()
+
+		cmds := make([]*redis.StringCmd, len(keys))
+
+		for i, key := range keys {
+
+			cmds[i] = pipe.Get(ctx, key)
+		}
+
+		_, err := pipe.Exec(ctx)
+		if err != nil && !errors.Is(err, redis.Nil) {
+
+			return values, err
+		}
+
+		for i, cmd := range cmds {
+
+			values[i] = cmd.Val()
+		}
+
+		return values, nil
+	}
+
+	data, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+
+		return values, err
+	}
+
+	for i, v := range data {
+
+		if s, ok := v.(string); ok {
+
+			values[i] = s
+		}
+	}
+
+	return values, nil
+}
+
+func (r *goRedis) Set(ctx context.Context, key, value string, expiry time.Duration) error {
+
+	return r.client.Set(ctx, key, value, expiry).Err()
+}
+
+func (r *goRedis) SetMany(ctx context.Context, values map[string]string) error {
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+
+		for key, value := range values {
+
+			pipe.Set(ctx, key, value, 0)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *goRedis) SetManyIncr(ctx context.Context, deletes []string, values map[string]string, counter string, seed int64, expiry time.Duration) (int64, error) {
+
+	var incr *redis.IntCmd
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+
+		for _, key := range deletes {
+
+			pipe.Del(ctx, key)
+		}
+
+		for key, value := range values {
+
+			pipe.Set(ctx, key, value, 0)
+		}
+
+		if seed > 0 {
+
+			pipe.SetNX(ctx, counter, seed, 0)
+		}
+
+		incr = pipe.Incr(ctx, counter)
+
+		if expiry > 0 {
+
+			pipe.Expire(ctx, counter, expiry)
+		}
+
+		return nil
+	})
+	if err != nil {
+
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *goRedis) Incr(ctx context.Context, key string, seed int64, expiry time.Duration) (int64, error) {
+
+	var incr *redis.IntCmd
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+
+		if seed > 0 {
+
+			pipe.SetNX(ctx, key, seed, 0)
+		}
+
+		incr = pipe.Incr(ctx, key)
+
+		if expiry > 0 {
+
+			pipe.Expire(ctx, key, expiry)
+		}
+
+		return nil
+	})
+	if err != nil {
+
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *goRedis) Del(ctx context.Context, keys ...string) (int64, error) {
+
+	// keys of a cluster may be in different slots, delete them one by one in a pipeline
+	if r.isCluster() {
+
+		pipe := r.client.Pipeline()
+
+		cmds := make([]*redis.IntCmd, len(keys))
+
+		for i, key := range keys {
+
+			cmds[i] = pipe.Del(ctx, key)
+		}
+
+		_, err := pipe.Exec(ctx)
+
+		deleted := int64(0)
+
+		for _, cmd := range cmds {
+
+			deleted += cmd.Val()
+		}
+
+		return deleted, err
+	}
+
+	return r.client.Del(ctx, keys...).Result()
+}
+
+func (r *goRedis) Exists(ctx context.Context, key string) (bool, error) {
+
+	check, err := r.client.Exists(ctx, key).Result()
+
+	return check > 0, err
+}
+
+func (r *goRedis) Scan(ctx context.Context, pattern string, fn func(key string) error) error {
+
+	scan := func(ctx context.Context, node redis.Cmdable) error {
+
+		iter := node.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+
+			if err := fn(iter.Val()); err != nil {
+
+				return err
+			}
+		}
+
+		return iter.Err()
+	}
+
+	// SCAN only sees the keys of one node, scan every master of a cluster
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+
+			return scan(ctx, node)
+		})
+	}
+
+	return scan(ctx, r.client)
+}
+
+func (r *goRedis) HSet(ctx context.Context, key, field, value string) error {
+
+	return r.client.HSet(ctx, key, field, value).Err()
+}
+
+func (r *goRedis) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+
+	return r.client.HGetAll(ctx, key).Result()
+}
+
+func (r *goRedis) HDel(ctx context.Context, key string, fields ...string) error {
+
+	return r.client.HDel(ctx, key, fields...).Err()
+}
+
+func (r *goRedis) LPush(ctx context.Context, key, value string, maxLength int64) error {
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+
+		pipe.LPush(ctx, key, value)
+
+		if maxLength > 0 {
+
+			pipe.LTrim(ctx, key, 0, maxLength-1)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *goRedis) Expire(ctx context.Context, key string, expiry time.Duration) error {
+
+	return r.client.Expire(ctx, key, expiry).Err()
+}
+
+func (r *goRedis) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+
+	return r.client.LRange(ctx, key, start, stop).Result()
+}
+
+func (r *goRedis) XAdd(ctx context.Context, stream string, values map[string]interface{}, maxLength int64) error {
+
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLength,
+		Approx: maxLength > 0,
+		Values: values,
+	}).Err()
+}
+
+func (r *goRedis) Close() error {
+
+	return r.client.Close()
+}
+
+func (r *goRedis) isCluster() bool {
+
+	_, ok := r.client.(*redis.ClusterClient)
+	return ok
+}
@@ -0,0 +1,342 @@
+package wsgateway
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/touchvas/odds-sdk/v2/models"
+	"golang.org/x/net/websocket"
+)
+
+// Request message of a client
+//
+//	{"action": "subscribe", "match_ids": [123, 456], "producer_id": 0}
+//	{"action": "unsubscribe", "match_ids": [123]}
+//
+// producer_id 0 follows the active producer of each match, a client gets a new snapshot when the producer changes
+type Request struct {
+	Action     string  `json:"action"`
+	MatchIDs   []int64 `json:"match_ids"`
+	ProducerID int64   `json:"producer_id"`
+}
+
+// Message message sent to a client, snapshot with all markets of a match, update with the markets changed
+// since the previous message of the match or error
+type Message struct {
+	Type       string          `json:"type"`
+	MatchID    int64           `json:"match_id,omitempty"`
+	ProducerID int64           `json:"producer_id,omitempty"`
+	Sequence   int64           `json:"sequence,omitempty"`
+	FullResync bool            `json:"full_resync,omitempty"`
+	Markets    []models.Market `json:"markets,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// subscription a match subscribed by a connection
+type subscription struct {
+
+	// producerID producer requested by the client, 0 for the active producer
+	producerID int64
+
+	// producer producer of the markets sent to the client
+	producer int64
+
+	// sequence sequence of the match in the last message sent
+	sequence int64
+
+	// snapshot the snapshot of the match was sent
+	snapshot bool
+}
+
+// connection a client, the reader applies subscribe requests and the writer is the only goroutine writing
+// to the websocket. Matches to send are collapsed in pending until the writer takes them
+type connection struct {
+	g  *Gateway
+	ws *websocket.Conn
+
+	mu            sync.Mutex
+	subscriptions map[int64]*subscription
+	pending       map[int64]struct{}
+	order         []int64
+
+	// signal has a value when there are pending matches
+	signal chan struct{}
+
+	// errors requests rejected by the reader, sent by the writer
+	errors chan string
+
+	done chan struct{}
+}
+
+func newConnection(g *Gateway, ws *websocket.Conn) *connection {
+
+	return &connection{
+		g:             g,
+		ws:            ws,
+		subscriptions: make(map[int64]*subscription),
+		pending:       make(map[int64]struct{}),
+		signal:        make(chan struct{}, 1),
+		errors:        make(chan string, 8),
+		done:          make(chan struct{}),
+	}
+}
+
+// run reads requests until the client disconnects
+func (c *connection) run() {
+
+	go c.write()
+
+	defer close(c.done)
+
+	for {
+
+		var req Request
+
+		err := websocket.JSON.Receive(c.ws, &req)
+		if err != nil {
+
+			return
+		}
+
+		switch req.Action {
+
+		case "subscribe":
+			c.subscribe(req)
+
+		case "unsubscribe":
+			c.unsubscribe(req)
+
+		default:
+			c.reject(fmt.Sprintf("unknown action %s", req.Action))
+
+		}
+	}
+}
+
+func (c *connection) subscribe(req Request) {
+
+	c.mu.Lock()
+
+	var added []int64
+
+	for _, matchID := range req.MatchIDs {
+
+		if _, ok := c.subscriptions[matchID]; ok {
+
+			continue
+		}
+
+		if len(c.subscriptions) >= c.g.cfg.MaxMatches {
+
+			c.mu.Unlock()
+			c.reject(fmt.Sprintf("at most %d matches per connection", c.g.cfg.MaxMatches))
+			c.mu.Lock()
+			break
+		}
+
+		c.subscriptions[matchID] = &subscription{producerID: req.ProducerID}
+		added = append(added, matchID)
+	}
+
+	c.mu.Unlock()
+
+	for _, matchID := range added {
+
+		c.g.subscribe(c, matchID)
+		c.notify(matchID)
+	}
+}
+
+func (c *connection) unsubscribe(req Request) {
+
+	for _, matchID := range req.MatchIDs {
+
+		c.mu.Lock()
+		delete(c.subscriptions, matchID)
+		c.mu.Unlock()
+
+		c.g.unsubscribe(c, matchID)
+	}
+}
+
+// reject sends an error to the client, errors are dropped when the client does not receive them
+func (c *connection) reject(message string) {
+
+	select {
+
+	case c.errors <- message:
+	default:
+	}
+}
+
+// notify marks the match as pending, it never blocks
+func (c *connection) notify(matchID int64) {
+
+	c.mu.Lock()
+
+	if _, ok := c.pending[matchID]; !ok {
+
+		c.pending[matchID] = struct{}{}
+		c.order = append(c.order, matchID)
+	}
+
+	c.mu.Unlock()
+
+	select {
+
+	case c.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (c *connection) take() []int64 {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	order := c.order
+
+	c.pending = make(map[int64]struct{})
+	c.order = nil
+
+	return order
+}
+
+func (c *connection) matchIDs() []int64 {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var matchIDs []int64
+
+	for matchID := range c.subscriptions {
+
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	return matchIDs
+}
+
+// write sends the pending matches until the client disconnects, a client too slow to receive is disconnected
+func (c *connection) write() {
+
+	defer c.ws.Close()
+
+	for {
+
+		select {
+
+		case <-c.done:
+			return
+
+		case message := <-c.errors:
+			if !c.send(Message{Type: "error", Error: message}) {
+
+				return
+			}
+
+		case <-c.signal:
+			for _, matchID := range c.take() {
+
+				if !c.sendMatch(matchID) {
+
+					return
+				}
+			}
+
+		}
+	}
+}
+
+// sendMatch sends a snapshot of the match before the first update and when the active producer changed,
+// else the markets changed since the last message of the match
+func (c *connection) sendMatch(matchID int64) bool {
+
+	state, ok := c.state(matchID)
+	if !ok {
+
+		return true
+	}
+
+	producerID := state.producerID
+	if producerID == 0 {
+
+		producerID, _ = c.g.feed.GetProducerID(matchID)
+		if producerID == 0 {
+
+			producerID = 3
+		}
+	}
+
+	var message Message
+
+	if !state.snapshot || producerID != state.producer {
+
+		markets, sequence := c.g.feed.GetAllMarketsWithSequence(producerID, matchID)
+
+		message = Message{Type: "snapshot", MatchID: matchID, ProducerID: producerID, Sequence: sequence, FullResync: true, Markets: markets}
+		atomic.AddInt64(&c.g.snapshots, 1)
+
+	} else {
+
+		changes := c.g.changes(producerID, matchID, state.sequence)
+		if changes.Sequence == state.sequence && !changes.FullResync {
+
+			return true
+		}
+
+		message = Message{Type: "update", MatchID: matchID, ProducerID: producerID, Sequence: changes.Sequence, FullResync: changes.FullResync, Markets: changes.Markets}
+		atomic.AddInt64(&c.g.updates, 1)
+	}
+
+	if !c.send(message) {
+
+		return false
+	}
+
+	c.mu.Lock()
+	if sub, ok := c.subscriptions[matchID]; ok {
+
+		sub.producer = producerID
+		sub.sequence = message.Sequence
+		sub.snapshot = true
+	}
+	c.mu.Unlock()
+
+	return true
+}
+
+// state gets a copy of the subscription of the match, ok is false when the match is no longer subscribed
+func (c *connection) state(matchID int64) (subscription, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub, ok := c.subscriptions[matchID]
+	if !ok {
+
+		return subscription{}, false
+	}
+
+	return *sub, true
+}
+
+func (c *connection) send(message Message) bool {
+
+	c.ws.SetWriteDeadline(time.Now().Add(c.g.cfg.WriteTimeout))
+
+	err := websocket.JSON.Send(c.ws, message)
+	if err != nil {
+
+		if netErr, ok := err.(interface{ Timeout() bool }); ok && netErr.Timeout() {
+
+			atomic.AddInt64(&c.g.slowClosed, 1)
+		}
+
+		return false
+	}
+
+	return true
+}
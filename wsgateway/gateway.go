@@ -0,0 +1,335 @@
+package wsgateway
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/touchvas/odds-sdk/v2/constants"
+	"github.com/touchvas/odds-sdk/v2/feeds"
+	"github.com/touchvas/odds-sdk/v2/models"
+	"github.com/touchvas/odds-sdk/v2/utils"
+	"golang.org/x/net/websocket"
+)
+
+// DefaultMaxConnections number of open connections when Config.MaxConnections is not set
+const DefaultMaxConnections = 10000
+
+// DefaultMaxMatches number of matches a connection can subscribe to when Config.MaxMatches is not set
+const DefaultMaxMatches = 200
+
+// DefaultWriteTimeout time a message can take to be written when Config.WriteTimeout is not set,
+// connections that can not receive a message within it are closed
+const DefaultWriteTimeout = 10 * time.Second
+
+// Config settings of the gateway
+type Config struct {
+
+	// MaxConnections maximum number of open connections, further connections are refused with 503
+	MaxConnections int
+
+	// MaxMatches maximum number of matches subscribed by a connection
+	MaxMatches int
+
+	// WriteTimeout time a message can take to be written to a connection
+	WriteTimeout time.Duration
+
+	// CheckOrigin optional check of the origin of a connection, all origins are accepted when not set
+	CheckOrigin func(r *http.Request) bool
+}
+
+// ConfigFromEnv gets the gateway settings from FEEDS_WS_MAX_CONNECTIONS, FEEDS_WS_MAX_MATCHES and FEEDS_WS_WRITE_TIMEOUT_MS
+func ConfigFromEnv() Config {
+
+	maxConnections, _ := strconv.Atoi(os.Getenv("FEEDS_WS_MAX_CONNECTIONS"))
+	maxMatches, _ := strconv.Atoi(os.Getenv("FEEDS_WS_MAX_MATCHES"))
+	writeTimeout, _ := strconv.ParseInt(os.Getenv("FEEDS_WS_WRITE_TIMEOUT_MS"), 10, 64)
+
+	return Config{
+		MaxConnections: maxConnections,
+		MaxMatches:     maxMatches,
+		WriteTimeout:   time.Duration(writeTimeout) * time.Millisecond,
+	}
+}
+
+// Stats counters of the gateway
+type Stats struct {
+	Connections int64 `json:"connections"`
+	Refused     int64 `json:"refused"`
+	Snapshots   int64 `json:"snapshots"`
+	Updates     int64 `json:"updates"`
+	SlowClosed  int64 `json:"slow_closed"`
+}
+
+// Gateway pushes the markets of subscribed matches to websocket clients. A client gets a snapshot of all markets
+// of a match when it subscribes and then the markets changed by every OddsChange and BetStop, driven by the
+// odds_invalidation messages and read with GetChangesSince. Changes of a match are collapsed while a client
+// is slow to receive, the client gets the markets changed since the last message it received, so a slow client
+// holds no more than one pending update per match. A client that can not receive a message within WriteTimeout
+// is disconnected
+type Gateway struct {
+	feed         feeds.Feed
+	cfg          Config
+	subscription *nats.Subscription
+
+	mu          sync.Mutex
+	open        map[*connection]struct{}
+	subscribers map[int64]map[*connection]struct{}
+	memo        map[int64]*matchChanges
+
+	connections int64
+	refused     int64
+	snapshots   int64
+	updates     int64
+	slowClosed  int64
+}
+
+// matchChanges changes of a match read since the last change of the match, shared by the connections of the match
+type matchChanges struct {
+	generation int64
+	changes    map[changesKey]models.MatchChanges
+}
+
+type changesKey struct {
+	producerID int64
+	since      int64
+}
+
+// New creates a gateway serving the markets of feed, changes are received over nc
+func New(feed feeds.Feed, nc *nats.Conn, cfg Config) (*Gateway, error) {
+
+	if cfg.MaxConnections <= 0 {
+
+		cfg.MaxConnections = DefaultMaxConnections
+	}
+
+	if cfg.MaxMatches <= 0 {
+
+		cfg.MaxMatches = DefaultMaxMatches
+	}
+
+	if cfg.WriteTimeout <= 0 {
+
+		cfg.WriteTimeout = DefaultWriteTimeout
+	}
+
+	g := &Gateway{
+		feed:        feed,
+		cfg:         cfg,
+		open:        make(map[*connection]struct{}),
+		subscribers: make(map[int64]map[*connection]struct{}),
+		memo:        make(map[int64]*matchChanges),
+	}
+
+	// a plain subscription, every instance has to receive every change
+	subscription, err := nc.Subscribe(utils.NatsSubject(constants.OddsInvalidationTopic), g.invalidated)
+	if err != nil {
+
+		log.Printf("error subscribing to odds invalidation %s", err.Error())
+		return nil, err
+	}
+
+	g.subscription = subscription
+
+	return g, nil
+}
+
+// ServeHTTP upgrades the request to a websocket connection, requests over MaxConnections are refused with 503
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if atomic.AddInt64(&g.connections, 1) > int64(g.cfg.MaxConnections) {
+
+		atomic.AddInt64(&g.connections, -1)
+		atomic.AddInt64(&g.refused, 1)
+		http.Error(w, "too many connections", http.StatusServiceUnavailable)
+		return
+	}
+
+	defer atomic.AddInt64(&g.connections, -1)
+
+	server := websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+
+			if g.cfg.CheckOrigin != nil && !g.cfg.CheckOrigin(r) {
+
+				return websocket.ErrBadWebSocketOrigin
+			}
+
+			return nil
+		},
+		Handler: g.serve,
+	}
+
+	server.ServeHTTP(w, r)
+}
+
+// Stats gets the counters of the gateway
+func (g *Gateway) Stats() Stats {
+
+	return Stats{
+		Connections: atomic.LoadInt64(&g.connections),
+		Refused:     atomic.LoadInt64(&g.refused),
+		Snapshots:   atomic.LoadInt64(&g.snapshots),
+		Updates:     atomic.LoadInt64(&g.updates),
+		SlowClosed:  atomic.LoadInt64(&g.slowClosed),
+	}
+}
+
+// Close stops receiving changes and closes all connections
+func (g *Gateway) Close() error {
+
+	err := g.subscription.Unsubscribe()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for c := range g.open {
+
+		c.ws.Close()
+	}
+
+	return err
+}
+
+func (g *Gateway) serve(ws *websocket.Conn) {
+
+	c := newConnection(g, ws)
+
+	g.mu.Lock()
+	g.open[c] = struct{}{}
+	g.mu.Unlock()
+
+	defer g.closed(c)
+
+	c.run()
+}
+
+// subscribe adds the connection to the subscribers of the match
+func (g *Gateway) subscribe(c *connection, matchID int64) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	subscribers, ok := g.subscribers[matchID]
+	if !ok {
+
+		subscribers = make(map[*connection]struct{})
+		g.subscribers[matchID] = subscribers
+	}
+
+	subscribers[c] = struct{}{}
+}
+
+func (g *Gateway) unsubscribe(c *connection, matchID int64) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.remove(c, matchID)
+}
+
+// closed removes a closed connection from the subscribers of its matches
+func (g *Gateway) closed(c *connection) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.open, c)
+
+	for _, matchID := range c.matchIDs() {
+
+		g.remove(c, matchID)
+	}
+}
+
+func (g *Gateway) remove(c *connection, matchID int64) {
+
+	delete(g.subscribers[matchID], c)
+
+	if len(g.subscribers[matchID]) == 0 {
+
+		delete(g.subscribers, matchID)
+		delete(g.memo, matchID)
+	}
+}
+
+// invalidated notifies the connections subscribed to the changed match
+func (g *Gateway) invalidated(msg *nats.Msg) {
+
+	var invalidation models.OddsInvalidation
+
+	err := json.Unmarshal(msg.Data, &invalidation)
+	if err != nil {
+
+		log.Printf("error decoding odds invalidation %s | %s", string(msg.Data), err.Error())
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	subscribers, ok := g.subscribers[invalidation.MatchID]
+	if !ok {
+
+		return
+	}
+
+	// changes read before this change are no longer shared
+	memo, ok := g.memo[invalidation.MatchID]
+	if !ok {
+
+		memo = &matchChanges{}
+		g.memo[invalidation.MatchID] = memo
+	}
+
+	memo.generation++
+	memo.changes = make(map[changesKey]models.MatchChanges)
+
+	for c := range subscribers {
+
+		c.notify(invalidation.MatchID)
+	}
+}
+
+// changes gets the markets of the match changed after since, connections at the same sequence share one read
+func (g *Gateway) changes(producerID, matchID, since int64) models.MatchChanges {
+
+	key := changesKey{producerID: producerID, since: since}
+
+	g.mu.Lock()
+
+	generation := int64(0)
+
+	memo, ok := g.memo[matchID]
+	if ok {
+
+		generation = memo.generation
+
+		if changes, ok := memo.changes[key]; ok {
+
+			g.mu.Unlock()
+			return changes
+		}
+	}
+
+	g.mu.Unlock()
+
+	changes := g.feed.GetChangesSince(producerID, matchID, since)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// a change received during the read may not be part of it
+	if memo, ok := g.memo[matchID]; ok && memo.generation == generation {
+
+		memo.changes[key] = changes
+	}
+
+	return changes
+}